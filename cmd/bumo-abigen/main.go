@@ -0,0 +1,54 @@
+// bumo-abigen generates Go bindings of BUMO contracts from contract interface
+// files.
+//
+//	bumo-abigen -in ctp10.json -pkg token -type Ctp10 -out ctp10.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+)
+
+func main() {
+	in := flag.String("in", "", "contract interface file")
+	pkg := flag.String("pkg", "", "package of the generated code")
+	typeName := flag.String("type", "", "type of the binding, the capitalized contract name by default")
+	out := flag.String("out", "", "output file, standard output by default")
+	flag.Parse()
+	if *in == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	data, err := ioutil.ReadFile(*in)
+	if err != nil {
+		fail(err.Error())
+	}
+	contractInterface, SDKRes := contract.LoadInterface(data)
+	if SDKRes.ErrorCode != 0 {
+		fail(SDKRes.ErrorDesc)
+	}
+	if *typeName == "" {
+		*typeName = contract.BindingTypeName(contractInterface.Name)
+	}
+	source, SDKRes := contract.GenerateBinding(contractInterface, *pkg, *typeName)
+	if SDKRes.ErrorCode != 0 {
+		fail(SDKRes.ErrorDesc)
+	}
+	if *out == "" {
+		os.Stdout.Write(source)
+		return
+	}
+	err = ioutil.WriteFile(*out, source, 0644)
+	if err != nil {
+		fail(err.Error())
+	}
+}
+
+func fail(desc string) {
+	fmt.Fprintln(os.Stderr, "bumo-abigen:", desc)
+	os.Exit(1)
+}
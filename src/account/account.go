@@ -12,13 +12,16 @@ import (
 )
 
 type AccountOperation struct {
-	Url string
+	Url       string
+	Network   model.Network
+	Snapshots SnapshotStore
+	History   HistoryStore
 }
 
 // Check the validity of the address
 func (account *AccountOperation) CheckValid(reqData model.AccountCheckValidRequest) model.AccountCheckValidResponse {
 	var resData model.AccountCheckValidResponse
-	resData.Result.IsValid = keypair.CheckAddress(reqData.GetAddress())
+	resData.Result.IsValid = account.Network.CheckAddress(reqData.GetAddress())
 	resData.ErrorCode = exception.SUCCESS
 	return resData
 }
@@ -27,7 +30,10 @@ func (account *AccountOperation) CheckValid(reqData model.AccountCheckValidReque
 func (account *AccountOperation) Create() model.AccountCreateResponse {
 	var resData model.AccountCreateResponse
 	var err error
-	resData.Result.PublicKey, resData.Result.PrivateKey, resData.Result.Address, err = keypair.Create()
+	resData.Result.PublicKey, resData.Result.PrivateKey, _, err = keypair.Create()
+	if err == nil {
+		resData.Result.Address, err = account.Network.EncodeAddress(resData.Result.PublicKey)
+	}
 	if err != nil {
 		resData.ErrorCode = exception.ACCOUNT_CREATE_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
@@ -40,7 +46,7 @@ func (account *AccountOperation) Create() model.AccountCreateResponse {
 // Get account info
 func (account *AccountOperation) GetInfo(reqData model.AccountGetInfoRequest) model.AccountGetInfoResponse {
 	var resData model.AccountGetInfoResponse
-	if !keypair.CheckAddress(reqData.GetAddress()) {
+	if !account.Network.CheckAddress(reqData.GetAddress()) {
 		resData.ErrorCode = exception.INVALID_ADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -81,7 +87,7 @@ func (account *AccountOperation) GetInfo(reqData model.AccountGetInfoRequest) mo
 // Get Nonce
 func (account *AccountOperation) GetNonce(reqData model.AccountGetNonceRequest) model.AccountGetNonceResponse {
 	var resData model.AccountGetNonceResponse
-	if !keypair.CheckAddress(reqData.GetAddress()) {
+	if !account.Network.CheckAddress(reqData.GetAddress()) {
 		SDKRes := exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -126,7 +132,7 @@ func (account *AccountOperation) GetNonce(reqData model.AccountGetNonceRequest)
 // Get Balance
 func (account *AccountOperation) GetBalance(reqData model.AccountGetBalanceRequest) model.AccountGetBalanceResponse {
 	var resData model.AccountGetBalanceResponse
-	if !keypair.CheckAddress(reqData.GetAddress()) {
+	if !account.Network.CheckAddress(reqData.GetAddress()) {
 		SDKRes := exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -172,7 +178,7 @@ func (account *AccountOperation) GetBalance(reqData model.AccountGetBalanceReque
 // Get Assets
 func (account *AccountOperation) GetAssets(reqData model.AccountGetAssetsRequest) model.AccountGetAssetsResponse {
 	var resData model.AccountGetAssetsResponse
-	if !keypair.CheckAddress(reqData.GetAddress()) {
+	if !account.Network.CheckAddress(reqData.GetAddress()) {
 		resData.ErrorCode = exception.INVALID_ADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -220,7 +226,7 @@ func (account *AccountOperation) GetAssets(reqData model.AccountGetAssetsRequest
 // Get Metadata
 func (account *AccountOperation) GetMetadata(reqData model.AccountGetMetadataRequest) model.AccountGetMetadataResponse {
 	var resData model.AccountGetMetadataResponse
-	if !keypair.CheckAddress(reqData.GetAddress()) {
+	if !account.Network.CheckAddress(reqData.GetAddress()) {
 		SDKRes := exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
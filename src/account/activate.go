@@ -0,0 +1,251 @@
+// activate
+package account
+
+import (
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/common"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/merkle"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const (
+	defaultActivateTimeout = 60 * time.Second
+	// how often ActivateBatch asks the node whether the transactions are confirmed
+	activatePollInterval = time.Second
+)
+
+// ActivateBatch activates many new accounts with as few transactions as the
+// operation and size limits of a transaction allow. Every account gets the
+// init balance, the base reserve of the chain by default, and the metadatas
+// and privilege of the request. Invalid, duplicate and already activated
+// addresses are skipped. The transactions take consecutive nonces of the
+// source account; when one cannot be submitted, the later ones are not
+// either. Each address gets its own outcome once its transaction is in a
+// ledger, or TRANSACTION_TIMEOUT_ERROR after the timeout (60 seconds by
+// default).
+func (account *AccountOperation) ActivateBatch(reqData model.AccountActivateBatchRequest) model.AccountActivateBatchResponse {
+	var resData model.AccountActivateBatchResponse
+	if !account.Network.CheckAddress(reqData.GetSourceAddress()) {
+		SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if len(reqData.GetPrivateKeys()) == 0 {
+		SDKRes := exception.GetSDKRes(exception.PRIVATEKEY_NULL_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	gasPrice, baseReserve, SDKRes := common.GetLatestFees(account.Url)
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	initBalance := reqData.GetInitBalance()
+	if initBalance == 0 {
+		initBalance = baseReserve
+	}
+	if initBalance < baseReserve {
+		SDKRes := exception.GetSDKRes(exception.INVALID_INITBALANCE_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc + ": the base reserve is " + strconv.FormatInt(baseReserve, 10)
+		return resData
+	}
+	resData.Result.InitBalance = initBalance
+
+	activations := make([]model.AccountActivation, len(reqData.GetDestAddresses()))
+	fail := func(i int, SDKRes exception.SDKResponse) {
+		activations[i].ErrorCode = SDKRes.ErrorCode
+		activations[i].ErrorDesc = SDKRes.ErrorDesc
+	}
+	var operations []model.BaseOperation
+	var sizes []int
+	// the activation of each operation
+	var indexes []int
+	seen := make(map[string]bool)
+	for i, address := range reqData.GetDestAddresses() {
+		activations[i].Address = address
+		if seen[address] {
+			SDKRes := exception.GetSDKRes(exception.INVALID_DESTADDRESS_ERROR)
+			SDKRes.ErrorDesc += ": duplicate address"
+			fail(i, SDKRes)
+			continue
+		}
+		seen[address] = true
+		var operation model.AccountActivateOperation
+		operation.Init()
+		operation.SetDestAddress(address)
+		operation.SetInitBalance(initBalance)
+		if reqData.GetPriv() != nil {
+			operation.SetPriv(*reqData.GetPriv())
+		}
+		for _, metadata := range reqData.GetAccountMetadatas() {
+			operation.AddAccountMetadata(metadata.Key, metadata.Value)
+		}
+		resDataActivate := common.Activate(operation, account.Url, account.Network)
+		if resDataActivate.ErrorCode == exception.INVALID_DESTADDRESS_ERROR {
+			fail(i, exception.SDKResponse{ErrorCode: resDataActivate.ErrorCode, ErrorDesc: resDataActivate.ErrorDesc})
+			continue
+		}
+		if resDataActivate.ErrorCode != 0 {
+			// the privilege or the metadatas, the same for every account
+			resData.ErrorCode = resDataActivate.ErrorCode
+			resData.ErrorDesc = resDataActivate.ErrorDesc
+			return resData
+		}
+		activated, SDKRes := common.CheckActivated(address, account.Url, account.Network)
+		if SDKRes.ErrorCode != 0 {
+			fail(i, SDKRes)
+			continue
+		}
+		if activated {
+			fail(i, exception.GetSDKRes(exception.ACCOUNT_ACTIVATED_ERROR))
+			continue
+		}
+		operations = append(operations, operation)
+		sizes = append(sizes, blockchain.OperationSize(&resDataActivate.Result.Operation))
+		indexes = append(indexes, i)
+	}
+	resData.Result.Activations = activations
+	if len(operations) == 0 {
+		return resData
+	}
+
+	var reqDataNonce model.AccountGetNonceRequest
+	reqDataNonce.SetAddress(reqData.GetSourceAddress())
+	resDataNonce := account.GetNonce(reqDataNonce)
+	if resDataNonce.ErrorCode != 0 {
+		resData.ErrorCode = resDataNonce.ErrorCode
+		resData.ErrorDesc = resDataNonce.ErrorDesc
+		return resData
+	}
+	nonce := resDataNonce.Result.Nonce
+	estimator := &blockchain.FeeEstimator{Url: account.Url, Network: account.Network, FeeConfig: protocol.FeeConfig{GasPrice: gasPrice, BaseReserve: baseReserve}}
+	envelope := blockchain.EnvelopeSize(reqData.GetSourceAddress(), reqData.GetMetadata(), len(reqData.GetPrivateKeys()))
+	// the activations of each submitted transaction
+	pending := make(map[string][]int)
+	for _, count := range blockchain.PackOperations(sizes, envelope, 0, 0) {
+		nonce++
+		hash, SDKRes := account.submitActivations(reqData, nonce, operations[:count], estimator)
+		for _, i := range indexes[:count] {
+			activations[i].Hash = hash
+		}
+		if SDKRes.ErrorCode != 0 {
+			// the later transactions would leave a gap in the nonces
+			for _, i := range indexes {
+				fail(i, SDKRes)
+			}
+			break
+		}
+		pending[hash] = indexes[:count]
+		operations, indexes = operations[count:], indexes[count:]
+	}
+	account.waitActivations(activations, pending, reqData.GetTimeout())
+	return resData
+}
+
+// submitActivations signs and submits a transaction of the operations. The
+// hash is returned once the transaction is signed.
+func (account *AccountOperation) submitActivations(reqData model.AccountActivateBatchRequest, nonce int64, operations []model.BaseOperation, estimator *blockchain.FeeEstimator) (string, exception.SDKResponse) {
+	gasPrice, feeLimit := reqData.GetGasPrice(), reqData.GetFeeLimit()
+	if gasPrice == 0 || feeLimit == 0 {
+		var reqDataFee model.TransactionEvaluateFeeRequest
+		reqDataFee.SetSourceAddress(reqData.GetSourceAddress())
+		reqDataFee.SetNonce(nonce)
+		reqDataFee.SetSignatureNumber(strconv.Itoa(len(reqData.GetPrivateKeys())))
+		reqDataFee.SetMetadata(reqData.GetMetadata())
+		for _, operation := range operations {
+			reqDataFee.AddOperation(operation)
+		}
+		resDataFee := estimator.EstimateFee(reqDataFee)
+		if resDataFee.ErrorCode != 0 {
+			return "", exception.SDKResponse{ErrorCode: resDataFee.ErrorCode, ErrorDesc: resDataFee.ErrorDesc}
+		}
+		if gasPrice == 0 {
+			gasPrice = resDataFee.Result.GasPrice
+		}
+		if feeLimit == 0 {
+			feeLimit = resDataFee.Result.FeeLimit
+		}
+	}
+	transaction := blockchain.TransactionOperation{Url: account.Url, Network: account.Network}
+	var reqDataBlob model.TransactionBuildBlobRequest
+	reqDataBlob.SetSourceAddress(reqData.GetSourceAddress())
+	reqDataBlob.SetNonce(nonce)
+	reqDataBlob.SetGasPrice(gasPrice)
+	reqDataBlob.SetFeeLimit(feeLimit)
+	reqDataBlob.SetMetadata(reqData.GetMetadata())
+	for _, operation := range operations {
+		reqDataBlob.AddOperation(operation)
+	}
+	resDataBlob := transaction.BuildBlob(reqDataBlob)
+	if resDataBlob.ErrorCode != 0 {
+		return "", exception.SDKResponse{ErrorCode: resDataBlob.ErrorCode, ErrorDesc: resDataBlob.ErrorDesc}
+	}
+	var reqDataSign model.TransactionSignRequest
+	reqDataSign.SetBlob(resDataBlob.Result.Blob)
+	reqDataSign.SetPrivateKeys(reqData.GetPrivateKeys())
+	resDataSign := transaction.Sign(reqDataSign)
+	if resDataSign.ErrorCode != 0 {
+		return "", exception.SDKResponse{ErrorCode: resDataSign.ErrorCode, ErrorDesc: resDataSign.ErrorDesc}
+	}
+	data, err := hex.DecodeString(resDataBlob.Result.Blob)
+	if err != nil {
+		return "", exception.GetSDKRes(exception.INVALID_BLOB_ERROR)
+	}
+	hash := hex.EncodeToString(merkle.Hash(data))
+	var reqDataSubmit model.TransactionSubmitRequest
+	reqDataSubmit.SetBlob(resDataBlob.Result.Blob)
+	reqDataSubmit.SetSignatures(resDataSign.Result.Signatures)
+	resDataSubmit := transaction.Submit(reqDataSubmit)
+	return hash, exception.SDKResponse{ErrorCode: resDataSubmit.ErrorCode, ErrorDesc: resDataSubmit.ErrorDesc}
+}
+
+// waitActivations looks up the pending transactions until all are in a
+// ledger or the timeout passes, and sets the outcome of their activations.
+func (account *AccountOperation) waitActivations(activations []model.AccountActivation, pending map[string][]int, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultActivateTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	transaction := blockchain.TransactionOperation{Url: account.Url, Network: account.Network}
+	for {
+		for hash, indexes := range pending {
+			var reqDataInfo model.TransactionGetInfoRequest
+			reqDataInfo.SetHash(hash)
+			resDataInfo := transaction.GetInfo(reqDataInfo)
+			// not found yet, or the node cannot be reached for now
+			if resDataInfo.ErrorCode != 0 || len(resDataInfo.Result.Transactions) == 0 {
+				continue
+			}
+			info := resDataInfo.Result.Transactions[0]
+			for _, i := range indexes {
+				activations[i].ErrorCode = int(info.ErrorCode)
+				activations[i].ErrorDesc = info.ErrorDesc
+			}
+			delete(pending, hash)
+		}
+		if len(pending) == 0 {
+			return
+		}
+		if time.Now().Add(activatePollInterval).After(deadline) {
+			break
+		}
+		time.Sleep(activatePollInterval)
+	}
+	SDKRes := exception.GetSDKRes(exception.TRANSACTION_TIMEOUT_ERROR)
+	for _, indexes := range pending {
+		for _, i := range indexes {
+			activations[i].ErrorCode = SDKRes.ErrorCode
+			activations[i].ErrorDesc = SDKRes.ErrorDesc
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// activate_test
+package account_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/golang/protobuf/proto"
+)
+
+// a node with a base reserve of 0.1 BU applying every submitted transaction,
+// where only the source and alice are activated
+type activateNode struct {
+	lock         sync.Mutex
+	source       string
+	applied      map[string]bool
+	transactions []protocol.Transaction
+}
+
+func (node *activateNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	node.lock.Lock()
+	defer node.lock.Unlock()
+	switch r.URL.Path {
+	case "/getLedger":
+		w.Write([]byte(`{"error_code":0,"result":{"fees":{"gas_price":1000,"base_reserve":10000000}}}`))
+	case "/getAccount":
+		address := r.URL.Query().Get("address")
+		if address != node.source && address != alice {
+			w.Write([]byte(`{"error_code":4}`))
+			return
+		}
+		w.Write([]byte(`{"error_code":0,"result":{"address":"` + address + `","nonce":5}}`))
+	case "/getTransactionHistory":
+		hash := r.URL.Query().Get("hash")
+		if !node.applied[hash] {
+			w.Write([]byte(`{"error_code":4}`))
+			return
+		}
+		w.Write([]byte(`{"error_code":0,"result":{"total_count":1,"transactions":[{"error_code":0,"hash":"` + hash + `"}]}}`))
+	case "/submitTransaction":
+		var request struct {
+			Items []struct {
+				Blob string `json:"transaction_blob"`
+			} `json:"items"`
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &request)
+		blob, _ := hex.DecodeString(request.Items[0].Blob)
+		sum := sha256.Sum256(blob)
+		hash := hex.EncodeToString(sum[:])
+		var transaction protocol.Transaction
+		proto.Unmarshal(blob, &transaction)
+		node.transactions = append(node.transactions, transaction)
+		node.applied[hash] = true
+		w.Write([]byte(`{"results":[{"error_code":0,"hash":"` + hash + `"}],"success_count":1}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func Test_ActivateBatch(t *testing.T) {
+	_, privateKey, source, _ := keypair.Create()
+	node := &activateNode{source: source, applied: make(map[string]bool)}
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	// one more than a transaction holds, an activated and a duplicate address
+	var addresses []string
+	for i := 0; i < 1001; i++ {
+		_, _, address, _ := keypair.Create()
+		addresses = append(addresses, address)
+	}
+	addresses = append(addresses, alice, addresses[0], "bu")
+	Account := account.AccountOperation{Url: server.URL}
+	var reqData model.AccountActivateBatchRequest
+	reqData.SetSourceAddress(source)
+	reqData.SetPrivateKeys([]string{privateKey})
+	reqData.SetDestAddresses(addresses)
+	reqData.AddAccountMetadata("kyc", "pending")
+	reqData.SetPriv(model.Priv{MasterWeight: 1, Signers: []model.Signer{{Address: bob, Weight: 1}}, Thresholds: model.Threshold{TxThreshold: 2}})
+	reqData.SetTimeout(time.Second)
+	resData := Account.ActivateBatch(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	if resData.Result.InitBalance != 10000000 || len(resData.Result.Activations) != len(addresses) {
+		t.Fatalf("wrong result %d %d", resData.Result.InitBalance, len(resData.Result.Activations))
+	}
+	activations := resData.Result.Activations
+	for i, activation := range activations[:1001] {
+		if activation.ErrorCode != 0 || activation.Address != addresses[i] || activation.Hash == "" {
+			t.Fatalf("wrong activation %+v", activation)
+		}
+	}
+	if activations[1001].ErrorCode != 11093 || activations[1002].ErrorCode != 11003 || activations[1003].ErrorCode != 11003 {
+		t.Errorf("wrong skipped activations %+v", activations[1001:])
+	}
+
+	if len(node.transactions) != 2 || node.transactions[0].Nonce != 6 || node.transactions[1].Nonce != 7 {
+		t.Fatalf("%d transactions submitted", len(node.transactions))
+	}
+	if len(node.transactions[0].Operations) != 1000 || len(node.transactions[1].Operations) != 1 {
+		t.Errorf("wrong packing %d %d", len(node.transactions[0].Operations), len(node.transactions[1].Operations))
+	}
+	create := node.transactions[1].Operations[0].GetCreateAccount()
+	if create.GetDestAddress() != addresses[1000] || create.GetInitBalance() != 10000000 {
+		t.Errorf("wrong operation %+v", create)
+	}
+	if create.GetPriv().GetThresholds().GetTxThreshold() != 2 || len(create.GetPriv().GetSigners()) != 1 || create.GetMetadatas()[0].GetValue() != "pending" {
+		t.Errorf("wrong privilege or metadatas %+v", create)
+	}
+
+	reqData.SetInitBalance(1)
+	if resData = Account.ActivateBatch(reqData); resData.ErrorCode != 11004 {
+		t.Error("init balance below the base reserve is accepted:", resData.ErrorDesc)
+	}
+}
@@ -0,0 +1,233 @@
+// history
+package account
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// Transfer types and directions of model.AccountTransfer
+const (
+	TRANSFER_FEE   = "fee"
+	TRANSFER_BU    = "bu"
+	TRANSFER_ASSET = "asset"
+
+	DIRECTION_IN   = "in"
+	DIRECTION_OUT  = "out"
+	DIRECTION_SELF = "self"
+)
+
+const maxHistoryLimit = 1000
+
+// HistoryStore keeps the transfers of accounts. A transfer is identified by
+// its address, ledger sequence and index, and adding it again replaces it, so
+// a ledger can be indexed twice.
+type HistoryStore interface {
+	Add(transfers []model.AccountTransfer) error
+	// List returns the transfers of the address from ledger start to ledger
+	// end, both included, ordered by ledger and index. An end of 0 has no upper
+	// bound. The first offset transfers are skipped and at most limit are
+	// returned, along with the total count in the range.
+	List(address string, start int64, end int64, offset int64, limit int64) ([]model.AccountTransfer, int64, error)
+}
+
+// MemoryHistoryStore is a HistoryStore kept in memory.
+type MemoryHistoryStore struct {
+	lock      sync.RWMutex
+	transfers map[string][]model.AccountTransfer
+}
+
+// NewMemoryHistoryStore
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{transfers: make(map[string][]model.AccountTransfer)}
+}
+
+// Add
+func (store *MemoryHistoryStore) Add(transfers []model.AccountTransfer) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	for _, transfer := range transfers {
+		list := store.transfers[transfer.Address]
+		i := sort.Search(len(list), func(i int) bool { return !transferBefore(list[i], transfer) })
+		if i < len(list) && list[i].LedgerSeq == transfer.LedgerSeq && list[i].Index == transfer.Index {
+			list[i] = transfer
+			continue
+		}
+		list = append(list, model.AccountTransfer{})
+		copy(list[i+1:], list[i:])
+		list[i] = transfer
+		store.transfers[transfer.Address] = list
+	}
+	return nil
+}
+
+// List
+func (store *MemoryHistoryStore) List(address string, start int64, end int64, offset int64, limit int64) ([]model.AccountTransfer, int64, error) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+	list := store.transfers[address]
+	first := sort.Search(len(list), func(i int) bool { return list[i].LedgerSeq >= start })
+	last := len(list)
+	if end != 0 {
+		last = sort.Search(len(list), func(i int) bool { return list[i].LedgerSeq > end })
+	}
+	if last < first {
+		last = first
+	}
+	total := int64(last - first)
+	from := first + int(offset)
+	if from > last {
+		from = last
+	}
+	to := last
+	if from+int(limit) < to {
+		to = from + int(limit)
+	}
+	page := make([]model.AccountTransfer, to-from)
+	copy(page, list[from:to])
+	return page, total, nil
+}
+
+func transferBefore(a model.AccountTransfer, b model.AccountTransfer) bool {
+	if a.LedgerSeq != b.LedgerSeq {
+		return a.LedgerSeq < b.LedgerSeq
+	}
+	return a.Index < b.Index
+}
+
+// HistoryIndexer normalizes the transactions of scanned ledgers into transfers
+// and adds them to a HistoryStore. Its Apply method is a
+// blockchain.LedgerHandler.
+//
+// Every transaction gives a fee transfer out of its source, and every
+// successful account creation, BU payment and asset payment gives a transfer
+// stored once for the sender and once for the receiver.
+type HistoryIndexer struct {
+	Store HistoryStore
+}
+
+// Apply
+func (indexer *HistoryIndexer) Apply(header model.GetInfoHeader, transactions []model.Transactioninfo) error {
+	if indexer.Store == nil {
+		return errors.New("history store is nil")
+	}
+	return indexer.Store.Add(NormalizeTransfers(header, transactions))
+}
+
+// NormalizeTransfers turns the transactions of one ledger into transfers. The
+// index numbers the transfers of the ledger in order, so it is stable across
+// scans of the same ledger.
+func NormalizeTransfers(header model.GetInfoHeader, transactions []model.Transactioninfo) []model.AccountTransfer {
+	var transfers []model.AccountTransfer
+	var index int64
+	add := func(transaction model.Transactioninfo, transfer model.AccountTransfer) {
+		transfer.Hash = transaction.Hash
+		transfer.Index = index
+		transfer.LedgerSeq = header.Number
+		transfer.CloseTime = header.CloseTime
+		transfer.ErrorCode = transaction.ErrorCode
+		index++
+		if transfer.Source == transfer.Dest {
+			transfer.Address = transfer.Source
+			transfer.Direction = DIRECTION_SELF
+			transfers = append(transfers, transfer)
+			return
+		}
+		transfer.Address = transfer.Source
+		transfer.Direction = DIRECTION_OUT
+		transfers = append(transfers, transfer)
+		if transfer.Dest != "" {
+			transfer.Address = transfer.Dest
+			transfer.Direction = DIRECTION_IN
+			transfers = append(transfers, transfer)
+		}
+	}
+	for _, transaction := range transactions {
+		if transaction.ActualFee != 0 {
+			add(transaction, model.AccountTransfer{
+				Type:   TRANSFER_FEE,
+				Source: transaction.Transaction.SourceAddress,
+				Amount: transaction.ActualFee,
+			})
+		}
+		if transaction.ErrorCode != 0 {
+			continue
+		}
+		for _, operation := range transaction.Transaction.Operations {
+			transfer := model.AccountTransfer{Source: operation.SourceAddress}
+			if transfer.Source == "" {
+				transfer.Source = transaction.Transaction.SourceAddress
+			}
+			switch protocol.Operation_Type(operation.Type) {
+			case protocol.Operation_CREATE_ACCOUNT:
+				transfer.Type = TRANSFER_BU
+				transfer.Dest = operation.CreateAccount.DestAddress
+				transfer.Amount = operation.CreateAccount.InitBalance
+			case protocol.Operation_PAY_COIN:
+				transfer.Type = TRANSFER_BU
+				transfer.Dest = operation.PayCoin.DestAddress
+				transfer.Amount = operation.PayCoin.Amount
+			case protocol.Operation_PAY_ASSET:
+				transfer.Type = TRANSFER_ASSET
+				transfer.Dest = operation.PayAsset.DestAddress
+				transfer.Amount = operation.PayAsset.Asset.Amount
+				transfer.AssetCode = operation.PayAsset.Asset.Key.Code
+				transfer.AssetIssuer = operation.PayAsset.Asset.Key.Issuer
+			default:
+				continue
+			}
+			add(transaction, transfer)
+		}
+	}
+	return transfers
+}
+
+// Get the transfers in and out of an account from the local history index
+func (account *AccountOperation) GetTransactionHistory(reqData model.AccountGetTransactionHistoryRequest) model.AccountGetTransactionHistoryResponse {
+	var resData model.AccountGetTransactionHistoryResponse
+	if !account.Network.CheckAddress(reqData.GetAddress()) {
+		SDKRes := exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if reqData.GetStartLedgerSeq() < 0 || reqData.GetEndLedgerSeq() < 0 ||
+		(reqData.GetEndLedgerSeq() != 0 && reqData.GetEndLedgerSeq() < reqData.GetStartLedgerSeq()) {
+		SDKRes := exception.GetSDKRes(exception.INVALID_BLOCKNUMBER_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	limit := reqData.GetLimit()
+	if limit == 0 {
+		limit = maxHistoryLimit
+	}
+	if reqData.GetOffset() < 0 || limit < 0 || limit > maxHistoryLimit {
+		SDKRes := exception.GetSDKRes(exception.INVALID_PAGINATION_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if account.History == nil {
+		SDKRes := exception.GetSDKRes(exception.HISTORY_STORE_NULL_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	transfers, total, err := account.History.List(reqData.GetAddress(), reqData.GetStartLedgerSeq(), reqData.GetEndLedgerSeq(), reqData.GetOffset(), limit)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = err.Error()
+		return resData
+	}
+	resData.Result.TotalCount = total
+	resData.Result.Transfers = transfers
+	resData.ErrorCode = exception.SUCCESS
+	return resData
+}
@@ -0,0 +1,81 @@
+// history_kv
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// KV is the part of an ordered embedded key-value store, such as a BoltDB
+// bucket, that KVHistoryStore needs.
+type KV interface {
+	Put(key []byte, value []byte) error
+	// Range calls fn for the keys from start, included, to end, excluded, in
+	// ascending byte order, until fn returns false.
+	Range(start []byte, end []byte, fn func(key []byte, value []byte) bool) error
+}
+
+// KVHistoryStore is a HistoryStore on top of an ordered key-value store. The
+// transfers are stored as JSON under address/ledger/index keys, with fixed
+// width numbers so the byte order is the ledger order.
+type KVHistoryStore struct {
+	KV KV
+}
+
+// NewKVHistoryStore
+func NewKVHistoryStore(kv KV) *KVHistoryStore {
+	return &KVHistoryStore{KV: kv}
+}
+
+func historyKey(address string, ledgerSeq int64, index int64) []byte {
+	return []byte(fmt.Sprintf("%s/%020d/%010d", address, ledgerSeq, index))
+}
+
+// Add
+func (store *KVHistoryStore) Add(transfers []model.AccountTransfer) error {
+	for _, transfer := range transfers {
+		value, err := json.Marshal(transfer)
+		if err != nil {
+			return err
+		}
+		err = store.KV.Put(historyKey(transfer.Address, transfer.LedgerSeq, transfer.Index), value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List
+func (store *KVHistoryStore) List(address string, start int64, end int64, offset int64, limit int64) ([]model.AccountTransfer, int64, error) {
+	// "0" sorts right after "/", so it bounds every key of the address
+	last := []byte(address + "0")
+	if end != 0 {
+		last = historyKey(address, end+1, 0)
+	}
+	var transfers []model.AccountTransfer
+	var total int64
+	var decodeErr error
+	err := store.KV.Range(historyKey(address, start, 0), last, func(key []byte, value []byte) bool {
+		total++
+		if total <= offset || int64(len(transfers)) >= limit {
+			return true
+		}
+		var transfer model.AccountTransfer
+		decodeErr = json.Unmarshal(value, &transfer)
+		if decodeErr != nil {
+			return false
+		}
+		transfers = append(transfers, transfer)
+		return true
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if decodeErr != nil {
+		return nil, 0, decodeErr
+	}
+	return transfers, total, nil
+}
@@ -0,0 +1,116 @@
+// history_sql
+package account
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// SQLHistoryStore is a HistoryStore in a SQL table. It uses only portable SQL,
+// so it runs on any database/sql driver. Placeholder renders the i-th (from 1)
+// query parameter; nil renders "?", and drivers such as PostgreSQL need
+// func(i int) string { return "$" + strconv.Itoa(i) }.
+type SQLHistoryStore struct {
+	DB          *sql.DB
+	Table       string
+	Placeholder func(i int) string
+}
+
+// NewSQLHistoryStore
+func NewSQLHistoryStore(db *sql.DB, table string) *SQLHistoryStore {
+	return &SQLHistoryStore{DB: db, Table: table}
+}
+
+var historyColumns = []string{"address", "ledger_seq", "idx", "direction", "type", "hash", "close_time",
+	"error_code", "source", "dest", "amount", "asset_code", "asset_issuer"}
+
+// CreateTable creates the table and its index when they do not exist.
+func (store *SQLHistoryStore) CreateTable() error {
+	_, err := store.DB.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	address VARCHAR(64) NOT NULL,
+	ledger_seq BIGINT NOT NULL,
+	idx BIGINT NOT NULL,
+	direction VARCHAR(8) NOT NULL,
+	type VARCHAR(8) NOT NULL,
+	hash VARCHAR(64) NOT NULL,
+	close_time BIGINT NOT NULL,
+	error_code BIGINT NOT NULL,
+	source VARCHAR(64) NOT NULL,
+	dest VARCHAR(64) NOT NULL,
+	amount BIGINT NOT NULL,
+	asset_code VARCHAR(64) NOT NULL,
+	asset_issuer VARCHAR(64) NOT NULL,
+	PRIMARY KEY (address, ledger_seq, idx))`, store.Table))
+	return err
+}
+
+func (store *SQLHistoryStore) placeholders(from int, count int) string {
+	list := make([]string, count)
+	for i := range list {
+		if store.Placeholder == nil {
+			list[i] = "?"
+		} else {
+			list[i] = store.Placeholder(from + i)
+		}
+	}
+	return strings.Join(list, ", ")
+}
+
+// Add replaces the stored transfers inside one database transaction.
+func (store *SQLHistoryStore) Add(transfers []model.AccountTransfer) error {
+	tx, err := store.DB.Begin()
+	if err != nil {
+		return err
+	}
+	del := fmt.Sprintf("DELETE FROM %s WHERE address = %s AND ledger_seq = %s AND idx = %s",
+		store.Table, store.placeholders(1, 1), store.placeholders(2, 1), store.placeholders(3, 1))
+	ins := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		store.Table, strings.Join(historyColumns, ", "), store.placeholders(1, len(historyColumns)))
+	for _, t := range transfers {
+		_, err = tx.Exec(del, t.Address, t.LedgerSeq, t.Index)
+		if err == nil {
+			_, err = tx.Exec(ins, t.Address, t.LedgerSeq, t.Index, t.Direction, t.Type, t.Hash, t.CloseTime,
+				t.ErrorCode, t.Source, t.Dest, t.Amount, t.AssetCode, t.AssetIssuer)
+		}
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// List
+func (store *SQLHistoryStore) List(address string, start int64, end int64, offset int64, limit int64) ([]model.AccountTransfer, int64, error) {
+	if end == 0 {
+		end = 1<<63 - 1
+	}
+	where := fmt.Sprintf(" FROM %s WHERE address = %s AND ledger_seq >= %s AND ledger_seq <= %s",
+		store.Table, store.placeholders(1, 1), store.placeholders(2, 1), store.placeholders(3, 1))
+	var total int64
+	err := store.DB.QueryRow("SELECT COUNT(*)"+where, address, start, end).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := fmt.Sprintf("SELECT %s%s ORDER BY ledger_seq, idx LIMIT %d OFFSET %d",
+		strings.Join(historyColumns, ", "), where, limit, offset)
+	rows, err := store.DB.Query(query, address, start, end)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	var transfers []model.AccountTransfer
+	for rows.Next() {
+		var t model.AccountTransfer
+		err = rows.Scan(&t.Address, &t.LedgerSeq, &t.Index, &t.Direction, &t.Type, &t.Hash, &t.CloseTime,
+			&t.ErrorCode, &t.Source, &t.Dest, &t.Amount, &t.AssetCode, &t.AssetIssuer)
+		if err != nil {
+			return nil, 0, err
+		}
+		transfers = append(transfers, t)
+	}
+	return transfers, total, rows.Err()
+}
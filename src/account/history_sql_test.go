@@ -0,0 +1,286 @@
+// history_sql_test
+package account_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// historyDriver is an in-process database/sql driver running the statements
+// of SQLHistoryStore on tables in memory. Each name opens its own database.
+type historyDriver struct {
+	lock      sync.Mutex
+	databases map[string]*historyDatabase
+}
+
+type historyDatabase struct {
+	lock   sync.Mutex
+	tables map[string][][]driver.Value
+	// the tables before the open transaction, restored by a rollback
+	saved map[string][][]driver.Value
+}
+
+func init() {
+	sql.Register("history", &historyDriver{databases: make(map[string]*historyDatabase)})
+}
+
+func (d *historyDriver) Open(name string) (driver.Conn, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.databases[name] == nil {
+		d.databases[name] = &historyDatabase{tables: make(map[string][][]driver.Value)}
+	}
+	return &historyConn{db: d.databases[name]}, nil
+}
+
+type historyConn struct {
+	db *historyDatabase
+}
+
+var (
+	historyPlaceholder = regexp.MustCompile(`\?|\$\d+`)
+	historyCreate      = regexp.MustCompile(`^CREATE TABLE IF NOT EXISTS (\w+) \(`)
+	historyDelete      = regexp.MustCompile(`^DELETE FROM (\w+) WHERE address = \S+ AND ledger_seq = \S+ AND idx = \S+$`)
+	historyInsert      = regexp.MustCompile(`^INSERT INTO (\w+) \(([^)]*)\) VALUES \(([^)]*)\)$`)
+	historyCount       = regexp.MustCompile(`^SELECT COUNT\(\*\) FROM (\w+) WHERE address = \S+ AND ledger_seq >= \S+ AND ledger_seq <= \S+$`)
+	historySelect      = regexp.MustCompile(`^SELECT (.+) FROM (\w+) WHERE address = \S+ AND ledger_seq >= \S+ AND ledger_seq <= \S+ ORDER BY ledger_seq, idx LIMIT (\d+) OFFSET (\d+)$`)
+)
+
+var historyColumnList = "address, ledger_seq, idx, direction, type, hash, close_time, error_code, source, dest, amount, asset_code, asset_issuer"
+
+func (conn *historyConn) Prepare(query string) (driver.Stmt, error) {
+	placeholders := historyPlaceholder.FindAllString(query, -1)
+	// numbered placeholders must count from 1
+	for i, placeholder := range placeholders {
+		if placeholder != "?" && placeholder != "$"+strconv.Itoa(i+1) {
+			return nil, errors.New("unexpected placeholder " + placeholder)
+		}
+	}
+	return &historyStmt{conn: conn, query: query, inputs: len(placeholders)}, nil
+}
+
+func (conn *historyConn) Close() error {
+	return nil
+}
+
+func (conn *historyConn) Begin() (driver.Tx, error) {
+	db := conn.db
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	if db.saved != nil {
+		return nil, errors.New("transaction already open")
+	}
+	db.saved = make(map[string][][]driver.Value)
+	for name, rows := range db.tables {
+		db.saved[name] = append([][]driver.Value{}, rows...)
+	}
+	return conn, nil
+}
+
+func (conn *historyConn) Commit() error {
+	conn.db.lock.Lock()
+	defer conn.db.lock.Unlock()
+	conn.db.saved = nil
+	return nil
+}
+
+func (conn *historyConn) Rollback() error {
+	conn.db.lock.Lock()
+	defer conn.db.lock.Unlock()
+	conn.db.tables = conn.db.saved
+	conn.db.saved = nil
+	return nil
+}
+
+type historyStmt struct {
+	conn   *historyConn
+	query  string
+	inputs int
+}
+
+func (stmt *historyStmt) Close() error {
+	return nil
+}
+
+func (stmt *historyStmt) NumInput() int {
+	return stmt.inputs
+}
+
+// table returns the rows of a table that exists
+func (stmt *historyStmt) table(name string) ([][]driver.Value, error) {
+	rows, ok := stmt.conn.db.tables[name]
+	if !ok {
+		return nil, errors.New("no such table " + name)
+	}
+	return rows, nil
+}
+
+func (stmt *historyStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := stmt.conn.db
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	if match := historyCreate.FindStringSubmatch(stmt.query); match != nil {
+		if _, ok := db.tables[match[1]]; !ok {
+			db.tables[match[1]] = nil
+		}
+		return driver.RowsAffected(0), nil
+	}
+	if match := historyDelete.FindStringSubmatch(stmt.query); match != nil {
+		rows, err := stmt.table(match[1])
+		if err != nil {
+			return nil, err
+		}
+		var kept [][]driver.Value
+		for _, row := range rows {
+			if row[0] != args[0] || row[1] != args[1] || row[2] != args[2] {
+				kept = append(kept, row)
+			}
+		}
+		db.tables[match[1]] = kept
+		return driver.RowsAffected(len(rows) - len(kept)), nil
+	}
+	if match := historyInsert.FindStringSubmatch(stmt.query); match != nil {
+		rows, err := stmt.table(match[1])
+		if err != nil {
+			return nil, err
+		}
+		if match[2] != historyColumnList {
+			return nil, errors.New("unexpected columns " + match[2])
+		}
+		for _, arg := range args {
+			// the VARCHAR(64) columns
+			if value, ok := arg.(string); ok && len(value) > 64 {
+				return nil, errors.New("value too long")
+			}
+		}
+		for _, row := range rows {
+			if row[0] == args[0] && row[1] == args[1] && row[2] == args[2] {
+				return nil, errors.New("duplicate primary key")
+			}
+		}
+		db.tables[match[1]] = append(rows, append([]driver.Value{}, args...))
+		return driver.RowsAffected(1), nil
+	}
+	return nil, errors.New("unexpected statement " + stmt.query)
+}
+
+func (stmt *historyStmt) Query(args []driver.Value) (driver.Rows, error) {
+	db := stmt.conn.db
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	name, columns := "", []string{"count"}
+	var limit, offset int
+	if match := historyCount.FindStringSubmatch(stmt.query); match != nil {
+		name = match[1]
+	} else if match := historySelect.FindStringSubmatch(stmt.query); match != nil {
+		if match[1] != historyColumnList {
+			return nil, errors.New("unexpected columns " + match[1])
+		}
+		name, columns = match[2], strings.Split(match[1], ", ")
+		limit, _ = strconv.Atoi(match[3])
+		offset, _ = strconv.Atoi(match[4])
+	} else {
+		return nil, errors.New("unexpected statement " + stmt.query)
+	}
+	rows, err := stmt.table(name)
+	if err != nil {
+		return nil, err
+	}
+	var found [][]driver.Value
+	for _, row := range rows {
+		if row[0] == args[0] && row[1].(int64) >= args[1].(int64) && row[1].(int64) <= args[2].(int64) {
+			found = append(found, row)
+		}
+	}
+	if len(columns) == 1 {
+		return &historyRows{columns: columns, rows: [][]driver.Value{{int64(len(found))}}}, nil
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i][1].(int64) != found[j][1].(int64) {
+			return found[i][1].(int64) < found[j][1].(int64)
+		}
+		return found[i][2].(int64) < found[j][2].(int64)
+	})
+	if offset > len(found) {
+		offset = len(found)
+	}
+	found = found[offset:]
+	if limit < len(found) {
+		found = found[:limit]
+	}
+	return &historyRows{columns: columns, rows: found}, nil
+}
+
+type historyRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *historyRows) Columns() []string {
+	return rows.columns
+}
+
+func (rows *historyRows) Close() error {
+	return nil
+}
+
+func (rows *historyRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+	return nil
+}
+
+func newSQLHistoryStore(t *testing.T, name string) *account.SQLHistoryStore {
+	db, err := sql.Open("history", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := account.NewSQLHistoryStore(db, "history")
+	err = store.CreateTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// numbered placeholders, and a failed Add stores none of its transfers
+func Test_SQLHistoryStore(t *testing.T) {
+	store := newSQLHistoryStore(t, t.Name())
+	store.Placeholder = func(i int) string { return "$" + strconv.Itoa(i) }
+	long := model.AccountTransfer{Address: alice, LedgerSeq: 9, Type: "bu", Source: strings.Repeat("x", 65)}
+	err := store.Add(append(historyLedgers(), long))
+	if err == nil {
+		t.Fatal("too long value is stored")
+	}
+	_, total, err := store.List(alice, 0, 0, 0, 1000)
+	if err != nil || total != 0 {
+		t.Fatalf("%d transfers are kept after a failed add: %v", total, err)
+	}
+	err = store.Add(historyLedgers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, total, err := store.List(alice, 5, 5, 0, 1000)
+	if err != nil || total != 2 || len(page) != 2 || page[1] != historyLedgers()[len(historyLedgers())-2] {
+		t.Errorf("wrong page %+v, total %d: %v", page, total, err)
+	}
+
+	unknown := account.NewSQLHistoryStore(store.DB, "missing")
+	if _, _, err = unknown.List(alice, 0, 0, 0, 1000); err == nil {
+		t.Error("missing table is listed")
+	}
+}
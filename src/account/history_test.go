@@ -0,0 +1,134 @@
+// history_test
+package account_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// sortedKV is an ordered key-value store for the KVHistoryStore tests
+type sortedKV struct {
+	keys   []string
+	values map[string][]byte
+}
+
+func (kv *sortedKV) Put(key []byte, value []byte) error {
+	if kv.values == nil {
+		kv.values = make(map[string][]byte)
+	}
+	if _, ok := kv.values[string(key)]; !ok {
+		kv.keys = append(kv.keys, string(key))
+		sort.Strings(kv.keys)
+	}
+	kv.values[string(key)] = value
+	return nil
+}
+
+func (kv *sortedKV) Range(start []byte, end []byte, fn func(key []byte, value []byte) bool) error {
+	for _, key := range kv.keys {
+		if bytes.Compare([]byte(key), start) < 0 || bytes.Compare([]byte(key), end) >= 0 {
+			continue
+		}
+		if !fn([]byte(key), kv.values[key]) {
+			break
+		}
+	}
+	return nil
+}
+
+func historyLedgers() []model.AccountTransfer {
+	var transfers []model.AccountTransfer
+	for seq := int64(1); seq <= 5; seq++ {
+		tx := payCoin(alice, seq, 1000, bob, seq*100)
+		tx.Hash = "hash"
+		transfers = append(transfers, account.NormalizeTransfers(model.GetInfoHeader{Number: seq}, []model.Transactioninfo{tx})...)
+	}
+	return transfers
+}
+
+// a payment is a fee out of the source and a transfer stored for both sides
+func Test_NormalizeTransfers(t *testing.T) {
+	failed := payCoin(bob, 2, 500, alice, 7)
+	failed.ErrorCode = 151
+	self := payCoin(alice, 3, 0, alice, 9)
+	transfers := account.NormalizeTransfers(model.GetInfoHeader{Number: 8, CloseTime: 99},
+		[]model.Transactioninfo{payCoin(alice, 1, 1000, bob, 100), failed, self})
+	expected := []model.AccountTransfer{
+		{Address: alice, Direction: "out", Type: "fee", Index: 0, Source: alice, Amount: 1000},
+		{Address: alice, Direction: "out", Type: "bu", Index: 1, Source: alice, Dest: bob, Amount: 100},
+		{Address: bob, Direction: "in", Type: "bu", Index: 1, Source: alice, Dest: bob, Amount: 100},
+		{Address: bob, Direction: "out", Type: "fee", Index: 2, Source: bob, Amount: 500, ErrorCode: 151},
+		{Address: alice, Direction: "self", Type: "bu", Index: 3, Source: alice, Dest: alice, Amount: 9},
+	}
+	if len(transfers) != len(expected) {
+		t.Fatalf("got %d transfers, want %d", len(transfers), len(expected))
+	}
+	for i := range expected {
+		expected[i].LedgerSeq = 8
+		expected[i].CloseTime = 99
+		if transfers[i] != expected[i] {
+			t.Errorf("transfer %d is %+v, want %+v", i, transfers[i], expected[i])
+		}
+	}
+}
+
+// the stores page through the same range in ledger order
+func Test_HistoryStore(t *testing.T) {
+	stores := map[string]account.HistoryStore{
+		"memory": account.NewMemoryHistoryStore(),
+		"kv":     account.NewKVHistoryStore(&sortedKV{}),
+		"sql":    newSQLHistoryStore(t, t.Name()),
+	}
+	for name, store := range stores {
+		err := store.Add(historyLedgers())
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		// adding a ledger again must not duplicate it
+		store.Add(historyLedgers()[:3])
+		page, total, err := store.List(alice, 2, 4, 1, 3)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if total != 6 || len(page) != 3 {
+			t.Fatalf("%s: total %d, page %d", name, total, len(page))
+		}
+		if page[0].LedgerSeq != 2 || page[0].Type != "bu" || page[2].LedgerSeq != 3 || page[2].Amount != 300 {
+			t.Errorf("%s: wrong page %+v", name, page)
+		}
+		_, total, _ = store.List(bob, 0, 0, 0, 1000)
+		if total != 5 {
+			t.Errorf("%s: bob has %d transfers, want 5", name, total)
+		}
+		page, total, _ = store.List(bob, 6, 0, 0, 1000)
+		if total != 0 || len(page) != 0 {
+			t.Errorf("%s: transfers found after the last ledger", name)
+		}
+	}
+}
+
+func Test_GetTransactionHistory(t *testing.T) {
+	var accountOperation account.AccountOperation
+	var reqData model.AccountGetTransactionHistoryRequest
+	reqData.SetAddress(alice)
+	resData := accountOperation.GetTransactionHistory(reqData)
+	if resData.ErrorCode != 11070 {
+		t.Error("missing store is not reported:", resData.ErrorCode)
+	}
+	accountOperation.History = account.NewMemoryHistoryStore()
+	accountOperation.History.Add(historyLedgers())
+	reqData.SetLimit(1001)
+	resData = accountOperation.GetTransactionHistory(reqData)
+	if resData.ErrorCode != 11071 {
+		t.Error("invalid limit is accepted:", resData.ErrorCode)
+	}
+	reqData.SetLimit(0)
+	resData = accountOperation.GetTransactionHistory(reqData)
+	if resData.ErrorCode != 0 || resData.Result.TotalCount != 10 {
+		t.Error("history is wrong:", resData.ErrorDesc, resData.Result.TotalCount)
+	}
+}
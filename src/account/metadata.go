@@ -0,0 +1,253 @@
+// metadata
+package account
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// ANY_VERSION writes or deletes a metadata whatever its version on chain.
+const ANY_VERSION int64 = -1
+
+const (
+	maxMetadataKeySize   = 1024
+	maxMetadataValueSize = 256 * 1024
+)
+
+// MetadataStore reads the metadatas of an account as JSON values and builds
+// the operations that write them, to be signed by the account as part of any
+// transaction. Writes are optimistic: they carry the version the metadata had
+// when it was read, so the chain refuses the transaction when another write
+// came first.
+type MetadataStore struct {
+	Url     string
+	Network model.Network
+	Address string
+}
+
+// MetadataStore returns the metadata store of the account of address.
+func (account *AccountOperation) MetadataStore(address string) *MetadataStore {
+	return &MetadataStore{Url: account.Url, Network: account.Network, Address: address}
+}
+
+// Get decodes the value of the key into value, unless value is nil, and
+// returns the version of the key. A key that is not set gives
+// NO_METADATA_ERROR.
+func (store *MetadataStore) Get(key string, value interface{}) (int64, exception.SDKResponse) {
+	SDKRes := checkMetadataKey(key)
+	if SDKRes.ErrorCode != 0 {
+		return 0, SDKRes
+	}
+	metadatas, SDKRes := store.read(key)
+	if SDKRes.ErrorCode != 0 {
+		return 0, SDKRes
+	}
+	if len(metadatas) == 0 {
+		return 0, exception.GetSDKRes(exception.NO_METADATA_ERROR)
+	}
+	if value != nil {
+		err := json.Unmarshal([]byte(metadatas[0].Value), value)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.INVALID_METADATA_JSON_ERROR)
+			SDKRes.ErrorDesc += ": " + err.Error()
+			return metadatas[0].Version, SDKRes
+		}
+	}
+	return metadatas[0].Version, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// Set builds the operation writing value, encoded as JSON, to the key. The
+// version is the one the key has now, as returned by Get or List, or
+// ANY_VERSION; a key that is not set has none to check, and is written
+// whatever the version.
+func (store *MetadataStore) Set(key string, value interface{}, version int64) (model.AccountSetMetadataOperation, exception.SDKResponse) {
+	var operation model.AccountSetMetadataOperation
+	SDKRes := checkMetadataKey(key)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	if version < ANY_VERSION {
+		return operation, exception.GetSDKRes(exception.INVALID_DATAVERSION_ERROR)
+	}
+	data, SDKRes := encodeMetadata(value)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	operation.Init()
+	operation.SetSourceAddress(store.Address)
+	operation.SetKey(key)
+	operation.SetValue(string(data))
+	if version != ANY_VERSION {
+		// the chain checks the version against the one it has, 0 for none
+		operation.SetVersion(version)
+	}
+	return operation, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// Delete builds the operation deleting the key at the version it has now, or
+// whatever its version with ANY_VERSION.
+func (store *MetadataStore) Delete(key string, version int64) (model.AccountSetMetadataOperation, exception.SDKResponse) {
+	var operation model.AccountSetMetadataOperation
+	SDKRes := checkMetadataKey(key)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	if version < ANY_VERSION {
+		return operation, exception.GetSDKRes(exception.INVALID_DATAVERSION_ERROR)
+	}
+	operation.Init()
+	operation.SetSourceAddress(store.Address)
+	operation.SetKey(key)
+	operation.SetDeleteFlag(true)
+	if version != ANY_VERSION {
+		operation.SetVersion(version)
+	}
+	return operation, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// List returns all metadatas of the account, sorted by key, with their raw
+// values.
+func (store *MetadataStore) List() ([]model.Metadata, exception.SDKResponse) {
+	metadatas, SDKRes := store.read("")
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	sort.Slice(metadatas, func(i, j int) bool {
+		return metadatas[i].Key < metadatas[j].Key
+	})
+	return metadatas, SDKRes
+}
+
+// Keys returns the keys of all metadatas of the account, sorted.
+func (store *MetadataStore) Keys() ([]string, exception.SDKResponse) {
+	metadatas, SDKRes := store.List()
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	keys := make([]string, len(metadatas))
+	for i, metadata := range metadatas {
+		keys[i] = metadata.Key
+	}
+	return keys, SDKRes
+}
+
+// Diff builds the fewest operations bringing the metadatas of the account to
+// the desired values, in the order of the keys. Keys whose value on chain is
+// the same JSON are left alone, and the others are written at the version
+// read, so the transaction fails when the account changed in between. With
+// prune set, keys that are not desired are deleted.
+func (store *MetadataStore) Diff(desired map[string]interface{}, prune bool) ([]model.AccountSetMetadataOperation, exception.SDKResponse) {
+	metadatas, SDKRes := store.List()
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	current := make(map[string]model.Metadata, len(metadatas))
+	for _, metadata := range metadatas {
+		current[metadata.Key] = metadata
+	}
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	if prune {
+		for key := range current {
+			if _, ok := desired[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	var operations []model.AccountSetMetadataOperation
+	for _, key := range keys {
+		metadata, exists := current[key]
+		value, ok := desired[key]
+		if !ok {
+			operation, SDKRes := store.Delete(key, metadata.Version)
+			if SDKRes.ErrorCode != 0 {
+				return nil, SDKRes
+			}
+			operations = append(operations, operation)
+			continue
+		}
+		data, SDKRes := encodeMetadata(value)
+		if SDKRes.ErrorCode != 0 {
+			SDKRes.ErrorDesc += ": " + key
+			return nil, SDKRes
+		}
+		if exists && sameJSON([]byte(metadata.Value), data) {
+			continue
+		}
+		operation, SDKRes := store.Set(key, json.RawMessage(data), metadata.Version)
+		if SDKRes.ErrorCode != 0 {
+			return nil, SDKRes
+		}
+		operations = append(operations, operation)
+	}
+	return operations, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// read returns the metadata of the key, or all of them when the key is
+// empty; none when the account has none.
+func (store *MetadataStore) read(key string) ([]model.Metadata, exception.SDKResponse) {
+	if !store.Network.CheckAddress(store.Address) {
+		return nil, exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
+	}
+	account := AccountOperation{Url: store.Url, Network: store.Network}
+	var reqData model.AccountGetMetadataRequest
+	reqData.SetAddress(store.Address)
+	reqData.SetKey(key)
+	resData := account.GetMetadata(reqData)
+	if resData.ErrorCode == exception.NO_METADATA_ERROR {
+		return nil, exception.GetSDKRes(exception.SUCCESS)
+	}
+	if resData.ErrorCode != 0 {
+		return nil, exception.SDKResponse{ErrorCode: resData.ErrorCode, ErrorDesc: resData.ErrorDesc}
+	}
+	return resData.Result.Metadatas, exception.GetSDKRes(exception.SUCCESS)
+}
+
+func checkMetadataKey(key string) exception.SDKResponse {
+	if len(key) == 0 || len(key) > maxMetadataKeySize {
+		return exception.GetSDKRes(exception.INVALID_DATAKEY_ERROR)
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+func encodeMetadata(value interface{}) ([]byte, exception.SDKResponse) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.INVALID_METADATA_JSON_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return nil, SDKRes
+	}
+	if len(data) > maxMetadataValueSize {
+		return nil, exception.GetSDKRes(exception.INVALID_DATAVALUE_ERROR)
+	}
+	return data, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// sameJSON tells whether both are the same JSON value, whatever the spacing
+// and the order of the members; a value that is not JSON is never the same.
+func sameJSON(a []byte, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var valueA, valueB interface{}
+	decoder := json.NewDecoder(bytes.NewReader(a))
+	decoder.UseNumber()
+	if decoder.Decode(&valueA) != nil || decoder.More() {
+		return false
+	}
+	decoder = json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if decoder.Decode(&valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
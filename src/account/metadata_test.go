@@ -0,0 +1,129 @@
+// metadata_test
+package account_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// a node where alice has the metadatas, looked up by key or all together
+func metadataNode(metadatas []model.Metadata) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/getAccount" || query.Get("address") != alice {
+			w.Write([]byte(`{"error_code":4}`))
+			return
+		}
+		var found []model.Metadata
+		for _, metadata := range metadatas {
+			if query.Get("key") == "" || query.Get("key") == metadata.Key {
+				found = append(found, metadata)
+			}
+		}
+		var response struct {
+			ErrorCode int `json:"error_code"`
+			Result    struct {
+				Address   string           `json:"address"`
+				Metadatas []model.Metadata `json:"metadatas"`
+			} `json:"result"`
+		}
+		response.Result.Address = alice
+		response.Result.Metadatas = found
+		data, _ := json.Marshal(response)
+		w.Write(data)
+	}))
+}
+
+type profile struct {
+	Name  string   `json:"name"`
+	Tags  []string `json:"tags"`
+	Level int      `json:"level"`
+}
+
+func Test_MetadataStore(t *testing.T) {
+	server := metadataNode([]model.Metadata{
+		{Key: "profile", Value: `{"level":2, "name":"alice","tags":["a"]}`, Version: 3},
+		{Key: "limit", Value: `100`, Version: 1},
+		{Key: "a b", Value: `"spaced"`, Version: 1},
+		{Key: "raw", Value: `not json`, Version: 5},
+	})
+	defer server.Close()
+	Account := account.AccountOperation{Url: server.URL}
+	store := Account.MetadataStore(alice)
+
+	var value profile
+	version, SDKRes := store.Get("profile", &value)
+	if SDKRes.ErrorCode != 0 || version != 3 || value.Name != "alice" || value.Level != 2 {
+		t.Fatalf("wrong value %+v at %d: %s", value, version, SDKRes.ErrorDesc)
+	}
+	var spaced string
+	if _, SDKRes = store.Get("a b", &spaced); SDKRes.ErrorCode != 0 || spaced != "spaced" {
+		t.Errorf("wrong value %q %s", spaced, SDKRes.ErrorDesc)
+	}
+	if _, SDKRes = store.Get("missing", nil); SDKRes.ErrorCode != 11010 {
+		t.Error("missing key is found:", SDKRes.ErrorDesc)
+	}
+	if _, SDKRes = store.Get("raw", &value); SDKRes.ErrorCode != 11094 {
+		t.Error("value that is not JSON is decoded:", SDKRes.ErrorDesc)
+	}
+	keys, SDKRes := store.Keys()
+	if SDKRes.ErrorCode != 0 || strings.Join(keys, ",") != "a b,limit,profile,raw" {
+		t.Errorf("wrong keys %v", keys)
+	}
+
+	version, SDKRes = store.Get("limit", nil)
+	if SDKRes.ErrorCode != 0 || version != 1 {
+		t.Fatalf("wrong version %d: %s", version, SDKRes.ErrorDesc)
+	}
+	operation, SDKRes := store.Set("limit", 200, version)
+	if SDKRes.ErrorCode != 0 || operation.GetValue() != "200" || operation.GetVersion() != 1 || operation.GetSourceAddress() != alice {
+		t.Errorf("wrong operation %+v", operation)
+	}
+	if _, SDKRes = store.Set(strings.Repeat("k", 1025), 1, 0); SDKRes.ErrorCode != 11011 {
+		t.Error("long key is accepted")
+	}
+	if _, SDKRes = store.Set("big", strings.Repeat("v", 256*1024), 0); SDKRes.ErrorCode != 11012 {
+		t.Error("big value is accepted")
+	}
+	operation, SDKRes = store.Delete("limit", account.ANY_VERSION)
+	if SDKRes.ErrorCode != 0 || !operation.GetDeleteFlag() || operation.GetVersion() != 0 {
+		t.Errorf("wrong operation %+v", operation)
+	}
+
+	// the profile is the same JSON and is left alone, the limit changes, the
+	// color is new and the other keys are deleted
+	desired := map[string]interface{}{
+		"profile": profile{Name: "alice", Tags: []string{"a"}, Level: 2},
+		"limit":   150,
+		"color":   "blue",
+	}
+	operations, SDKRes := store.Diff(desired, true)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	var got []string
+	for _, operation := range operations {
+		if operation.GetDeleteFlag() {
+			got = append(got, "-"+operation.GetKey())
+		} else {
+			got = append(got, operation.GetKey()+"="+operation.GetValue())
+		}
+	}
+	if strings.Join(got, " ") != `-a b color="blue" limit=150 -raw` {
+		t.Errorf("wrong diff %v", got)
+	}
+	// written at the versions read, and the new key without one
+	if operations[0].GetVersion() != 1 || operations[1].GetVersion() != 0 || operations[2].GetVersion() != 1 || operations[3].GetVersion() != 5 {
+		t.Errorf("wrong versions %+v", operations)
+	}
+	operations, _ = store.Diff(desired, false)
+	if len(operations) != 2 {
+		t.Errorf("%d operations without pruning", len(operations))
+	}
+}
@@ -0,0 +1,330 @@
+// snapshot
+package account
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// SnapshotStore keeps the balance and nonce of accounts at the end of ledgers.
+type SnapshotStore interface {
+	// Put records the state of an account at the end of snapshot.LedgerSeq.
+	Put(snapshot model.AccountSnapshot) error
+	// Get returns the latest snapshot of the account at or before ledgerSeq. It
+	// reports no snapshot when ledgerSeq is beyond the ledgers known for the
+	// account, since a later change may not have been replayed.
+	Get(address string, ledgerSeq int64) (model.AccountSnapshot, bool, error)
+	// Advance records that ledgerSeq has been replayed, so every account known
+	// up to the ledger before it is known up to ledgerSeq.
+	Advance(ledgerSeq int64) error
+}
+
+// MemorySnapshotStore is a SnapshotStore kept in memory.
+type MemorySnapshotStore struct {
+	lock      sync.RWMutex
+	snapshots map[string][]model.AccountSnapshot
+	known     map[string][]knownLedgers
+}
+
+// knownLedgers is a range of ledgers, both included, at the end of which the
+// state of an account is known. It starts at a snapshot.
+type knownLedgers struct {
+	from int64
+	to   int64
+}
+
+// NewMemorySnapshotStore
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{snapshots: make(map[string][]model.AccountSnapshot), known: make(map[string][]knownLedgers)}
+}
+
+// Put
+// A snapshot outside the known ledgers of the account, such as a seed taken
+// past the replayed ones, starts a new known range; the ledgers between the
+// ranges stay unknown.
+func (store *MemorySnapshotStore) Put(snapshot model.AccountSnapshot) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	store.known[snapshot.Address] = addKnown(store.known[snapshot.Address], snapshot.LedgerSeq)
+	list := store.snapshots[snapshot.Address]
+	i := sort.Search(len(list), func(i int) bool { return list[i].LedgerSeq >= snapshot.LedgerSeq })
+	if i < len(list) && list[i].LedgerSeq == snapshot.LedgerSeq {
+		list[i] = snapshot
+		return nil
+	}
+	list = append(list, model.AccountSnapshot{})
+	copy(list[i+1:], list[i:])
+	list[i] = snapshot
+	store.snapshots[snapshot.Address] = list
+	return nil
+}
+
+// Get
+func (store *MemorySnapshotStore) Get(address string, ledgerSeq int64) (model.AccountSnapshot, bool, error) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+	ranges := store.known[address]
+	j := sort.Search(len(ranges), func(j int) bool { return ranges[j].from > ledgerSeq })
+	if j == 0 || ledgerSeq > ranges[j-1].to {
+		return model.AccountSnapshot{}, false, nil
+	}
+	list := store.snapshots[address]
+	i := sort.Search(len(list), func(i int) bool { return list[i].LedgerSeq > ledgerSeq })
+	if i == 0 {
+		return model.AccountSnapshot{}, false, nil
+	}
+	return list[i-1], true, nil
+}
+
+// Advance
+// Only a known range that ends at the ledger before ledgerSeq grows; the others
+// missed a ledger.
+func (store *MemorySnapshotStore) Advance(ledgerSeq int64) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	for address, ranges := range store.known {
+		for j := range ranges {
+			if ranges[j].to == ledgerSeq-1 {
+				store.known[address] = addKnown(ranges, ledgerSeq)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// addKnown adds a ledger to the sorted known ranges, joining the ranges it
+// closes the gap between.
+func addKnown(ranges []knownLedgers, ledgerSeq int64) []knownLedgers {
+	j := sort.Search(len(ranges), func(j int) bool { return ranges[j].from > ledgerSeq })
+	if j > 0 && ledgerSeq <= ranges[j-1].to {
+		return ranges
+	}
+	joinPrevious := j > 0 && ranges[j-1].to == ledgerSeq-1
+	joinNext := j < len(ranges) && ranges[j].from == ledgerSeq+1
+	switch {
+	case joinPrevious && joinNext:
+		ranges[j-1].to = ranges[j].to
+		return append(ranges[:j], ranges[j+1:]...)
+	case joinPrevious:
+		ranges[j-1].to = ledgerSeq
+		return ranges
+	case joinNext:
+		ranges[j].from = ledgerSeq
+		return ranges
+	}
+	ranges = append(ranges, knownLedgers{})
+	copy(ranges[j+1:], ranges[j:])
+	ranges[j] = knownLedgers{from: ledgerSeq, to: ledgerSeq}
+	return ranges
+}
+
+// SnapshotReplayer replays the balance changes of scanned ledgers into a
+// SnapshotStore. Its Apply method is a blockchain.LedgerHandler.
+//
+// Only accounts with a known state are followed: accounts created inside the
+// scanned range, and accounts seeded with AccountOperation.SaveSnapshot or a
+// Put before the scan starts. Fees, BU payments and account creation are
+// replayed; validator rewards are not part of any transaction, so validator
+// accounts should be re-seeded rather than replayed.
+type SnapshotReplayer struct {
+	Store SnapshotStore
+}
+
+// Apply replays the transactions of one ledger and records a snapshot of every
+// changed account at header.Number.
+func (replayer *SnapshotReplayer) Apply(header model.GetInfoHeader, transactions []model.Transactioninfo) error {
+	if replayer.Store == nil {
+		return errors.New("snapshot store is nil")
+	}
+	changed := make(map[string]*model.AccountSnapshot)
+	load := func(address string) (*model.AccountSnapshot, error) {
+		if snapshot, ok := changed[address]; ok {
+			return snapshot, nil
+		}
+		snapshot, ok, err := replayer.Store.Get(address, header.Number-1)
+		if err != nil || !ok {
+			return nil, err
+		}
+		changed[address] = &snapshot
+		return &snapshot, nil
+	}
+	for _, transaction := range transactions {
+		source, err := load(transaction.Transaction.SourceAddress)
+		if err != nil {
+			return err
+		}
+		if source != nil {
+			source.Balance -= transaction.ActualFee
+			if transaction.Transaction.Nonce > source.Nonce {
+				source.Nonce = transaction.Transaction.Nonce
+			}
+		}
+		if transaction.ErrorCode != 0 {
+			continue
+		}
+		for _, operation := range transaction.Transaction.Operations {
+			from := operation.SourceAddress
+			if from == "" {
+				from = transaction.Transaction.SourceAddress
+			}
+			var to string
+			var amount int64
+			switch protocol.Operation_Type(operation.Type) {
+			case protocol.Operation_CREATE_ACCOUNT:
+				to = operation.CreateAccount.DestAddress
+				amount = operation.CreateAccount.InitBalance
+				// a contract created without a destination gets an address
+				// the transaction does not show, so only the sender is replayed
+				if _, ok := changed[to]; !ok && to != "" {
+					changed[to] = &model.AccountSnapshot{Address: to}
+				}
+			case protocol.Operation_PAY_COIN:
+				to = operation.PayCoin.DestAddress
+				amount = operation.PayCoin.Amount
+			default:
+				continue
+			}
+			sender, err := load(from)
+			if err != nil {
+				return err
+			}
+			if sender != nil {
+				sender.Balance -= amount
+			}
+			if to == "" {
+				continue
+			}
+			receiver, err := load(to)
+			if err != nil {
+				return err
+			}
+			if receiver != nil {
+				receiver.Balance += amount
+			}
+		}
+	}
+	for _, snapshot := range changed {
+		snapshot.LedgerSeq = header.Number
+		err := replayer.Store.Put(*snapshot)
+		if err != nil {
+			return err
+		}
+	}
+	return replayer.Store.Advance(header.Number)
+}
+
+// Get the balance and nonce of an account at the end of a ledger
+func (account *AccountOperation) GetSnapshot(reqData model.AccountGetSnapshotRequest) model.AccountGetSnapshotResponse {
+	var resData model.AccountGetSnapshotResponse
+	if !account.Network.CheckAddress(reqData.GetAddress()) {
+		SDKRes := exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if reqData.GetLedgerSeq() <= 0 {
+		SDKRes := exception.GetSDKRes(exception.INVALID_BLOCKNUMBER_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if account.Snapshots != nil {
+		snapshot, ok, err := account.Snapshots.Get(reqData.GetAddress(), reqData.GetLedgerSeq())
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = err.Error()
+			return resData
+		}
+		if ok {
+			resData.Result = snapshot
+			resData.ErrorCode = exception.SUCCESS
+			return resData
+		}
+	}
+	// the node only serves the current state, which is the state at the latest ledger
+	current := account.currentSnapshot(reqData.GetAddress())
+	if current.ErrorCode != 0 {
+		return current
+	}
+	if current.Result.LedgerSeq > reqData.GetLedgerSeq() {
+		SDKRes := exception.GetSDKRes(exception.SNAPSHOT_NOT_FOUND_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	return current
+}
+
+// Save the current balance and nonce of an account as a snapshot, the seed of a replay
+func (account *AccountOperation) SaveSnapshot(reqData model.AccountGetInfoRequest) model.AccountGetSnapshotResponse {
+	var resData model.AccountGetSnapshotResponse
+	if account.Snapshots == nil {
+		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = "Snapshot store is nil"
+		return resData
+	}
+	resData = account.currentSnapshot(reqData.GetAddress())
+	if resData.ErrorCode != 0 {
+		return resData
+	}
+	err := account.Snapshots.Put(resData.Result)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = err.Error()
+		return resData
+	}
+	return resData
+}
+
+// currentSnapshot reads the account between two reads of the latest ledger and
+// retries when a ledger closed in between, so the state belongs to that ledger.
+func (account *AccountOperation) currentSnapshot(address string) model.AccountGetSnapshotResponse {
+	var resData model.AccountGetSnapshotResponse
+	block := blockchain.BlockOperation{Url: account.Url, Network: account.Network}
+	for i := 0; i < 3; i++ {
+		before := block.GetNumber()
+		if before.ErrorCode != 0 {
+			resData.ErrorCode = before.ErrorCode
+			resData.ErrorDesc = before.ErrorDesc
+			return resData
+		}
+		var reqDataInfo model.AccountGetInfoRequest
+		reqDataInfo.SetAddress(address)
+		resDataInfo := account.GetInfo(reqDataInfo)
+		if resDataInfo.ErrorCode != 0 {
+			resData.ErrorCode = resDataInfo.ErrorCode
+			resData.ErrorDesc = resDataInfo.ErrorDesc
+			return resData
+		}
+		after := block.GetNumber()
+		if after.ErrorCode != 0 {
+			resData.ErrorCode = after.ErrorCode
+			resData.ErrorDesc = after.ErrorDesc
+			return resData
+		}
+		if before.Result.Header.BlockNumber == after.Result.Header.BlockNumber {
+			resData.Result = model.AccountSnapshot{
+				Address:   address,
+				LedgerSeq: after.Result.Header.BlockNumber,
+				Balance:   resDataInfo.Result.Balance,
+				Nonce:     resDataInfo.Result.Nonce,
+			}
+			resData.ErrorCode = exception.SUCCESS
+			return resData
+		}
+	}
+	SDKRes := exception.GetSDKRes(exception.SNAPSHOT_NOT_FOUND_ERROR)
+	resData.ErrorCode = SDKRes.ErrorCode
+	resData.ErrorDesc = SDKRes.ErrorDesc
+	return resData
+}
@@ -0,0 +1,152 @@
+// snapshot_test
+package account_test
+
+import (
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const (
+	alice = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+	bob   = "buQBjJD1BSJ7nzAbzdTenAhpFjmxRVEEtmxH"
+	carol = "buQtfFxpQP9JCFgmu4WBojBbEnVyQGaJDgGn"
+)
+
+func payCoin(source string, nonce int64, fee int64, dest string, amount int64) model.Transactioninfo {
+	var transaction model.Transactioninfo
+	transaction.ActualFee = fee
+	transaction.Transaction.SourceAddress = source
+	transaction.Transaction.Nonce = nonce
+	transaction.Transaction.Operations = []model.Operation{{Type: 7, PayCoin: model.PayCoin{DestAddress: dest, Amount: amount}}}
+	return transaction
+}
+
+//the store answers with the latest snapshot at or before the ledger
+func Test_MemorySnapshotStore(t *testing.T) {
+	store := account.NewMemorySnapshotStore()
+	store.Put(model.AccountSnapshot{Address: alice, LedgerSeq: 10, Balance: 100})
+	for seq := int64(11); seq < 30; seq++ {
+		store.Advance(seq)
+	}
+	store.Put(model.AccountSnapshot{Address: alice, LedgerSeq: 30, Balance: 300})
+	store.Put(model.AccountSnapshot{Address: alice, LedgerSeq: 20, Balance: 200})
+	store.Advance(31)
+	cases := map[int64]int64{10: 100, 19: 100, 20: 200, 29: 200, 30: 300, 31: 300}
+	for seq, balance := range cases {
+		snapshot, ok, err := store.Get(alice, seq)
+		if err != nil || !ok || snapshot.Balance != balance {
+			t.Errorf("balance at %d is %d, want %d", seq, snapshot.Balance, balance)
+		}
+	}
+	_, ok, _ := store.Get(alice, 9)
+	if ok {
+		t.Error("snapshot before the first one is found")
+	}
+	_, ok, _ = store.Get(alice, 32)
+	if ok {
+		t.Error("snapshot beyond the replayed ledgers is found")
+	}
+
+	// a seed past a gap keeps the earlier ledgers, the gap stays unknown
+	store.Put(model.AccountSnapshot{Address: alice, LedgerSeq: 40, Balance: 400})
+	cases = map[int64]int64{15: 100, 31: 300, 40: 400}
+	for seq, balance := range cases {
+		snapshot, ok, _ := store.Get(alice, seq)
+		if !ok || snapshot.Balance != balance {
+			t.Errorf("balance at %d is %d, want %d", seq, snapshot.Balance, balance)
+		}
+	}
+	_, ok, _ = store.Get(alice, 35)
+	if ok {
+		t.Error("snapshot in the gap is found")
+	}
+	// replaying the gap joins the ranges
+	for seq := int64(32); seq < 40; seq++ {
+		store.Advance(seq)
+	}
+	store.Advance(41)
+	cases = map[int64]int64{35: 300, 39: 300, 41: 400}
+	for seq, balance := range cases {
+		snapshot, ok, _ := store.Get(alice, seq)
+		if !ok || snapshot.Balance != balance {
+			t.Errorf("balance at %d is %d, want %d", seq, snapshot.Balance, balance)
+		}
+	}
+}
+
+//fees, payments and created accounts are replayed, unknown accounts are skipped
+func Test_SnapshotReplayer(t *testing.T) {
+	store := account.NewMemorySnapshotStore()
+	store.Put(model.AccountSnapshot{Address: alice, LedgerSeq: 1, Balance: 1000000, Nonce: 5})
+	replayer := account.SnapshotReplayer{Store: store}
+
+	var create model.Transactioninfo
+	create.ActualFee = 1000
+	create.Transaction.SourceAddress = alice
+	create.Transaction.Nonce = 6
+	create.Transaction.Operations = []model.Operation{{Type: 1, CreateAccount: model.CreateAccount{DestAddress: bob, InitBalance: 50000}}}
+	err := replayer.Apply(model.GetInfoHeader{Number: 2}, []model.Transactioninfo{create})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = replayer.Apply(model.GetInfoHeader{Number: 3}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failed := payCoin(alice, 7, 1000, bob, 999999)
+	failed.ErrorCode = 151
+	err = replayer.Apply(model.GetInfoHeader{Number: 4}, []model.Transactioninfo{
+		payCoin(bob, 1, 1000, alice, 10000),
+		payCoin(alice, 8, 1000, carol, 3000),
+		failed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []model.AccountSnapshot{
+		{Address: alice, LedgerSeq: 2, Balance: 949000, Nonce: 6},
+		{Address: bob, LedgerSeq: 2, Balance: 50000, Nonce: 0},
+		{Address: alice, LedgerSeq: 3, Balance: 949000, Nonce: 6},
+		{Address: alice, LedgerSeq: 4, Balance: 954000, Nonce: 8},
+		{Address: bob, LedgerSeq: 4, Balance: 39000, Nonce: 1},
+	}
+	for _, want := range expected {
+		snapshot, ok, _ := store.Get(want.Address, want.LedgerSeq)
+		snapshot.LedgerSeq = want.LedgerSeq
+		if !ok || snapshot != want {
+			t.Errorf("snapshot is %+v, want %+v", snapshot, want)
+		}
+	}
+	_, ok, _ := store.Get(carol, 4)
+	if ok {
+		t.Error("account without a seed is replayed")
+	}
+	_, ok, _ = store.Get(alice, 5)
+	if ok {
+		t.Error("snapshot beyond the replayed ledgers is found")
+	}
+
+	// a contract created without a destination only costs the sender
+	var contract model.Transactioninfo
+	contract.ActualFee = 1000
+	contract.Transaction.SourceAddress = alice
+	contract.Transaction.Nonce = 9
+	contract.Transaction.Operations = []model.Operation{{Type: 1, CreateAccount: model.CreateAccount{InitBalance: 3000}}}
+	err = replayer.Apply(model.GetInfoHeader{Number: 5}, []model.Transactioninfo{contract})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, ok, _ := store.Get(alice, 5)
+	if !ok || snapshot.Balance != 950000 || snapshot.Nonce != 9 {
+		t.Errorf("snapshot is %+v", snapshot)
+	}
+	_, ok, _ = store.Get("", 5)
+	if ok {
+		t.Error("contract without an address is replayed")
+	}
+}
@@ -3,18 +3,27 @@ package blockchain
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"strconv"
 
 	"github.com/bumoproject/bumo-sdk-go/src/common"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/merkle"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
 	"github.com/bumoproject/bumo-sdk-go/src/exception"
 	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/golang/protobuf/jsonpb"
 )
 
 type BlockOperation struct {
 	Url string
+	// the chain of the node; the zero value is the main network
+	Network model.Network
 }
 
+const closeIntervalWindow int64 = 60
+
 // get number
 func (block *BlockOperation) GetNumber() model.BlockGetNumberResponse {
 	var resData model.BlockGetNumberResponse
@@ -333,14 +342,15 @@ func (block *BlockOperation) GetReward(reqData model.BlockGetRewardRequest) mode
 	rewardGetInput.Method = "getRewardDistribute";
 	rewardGetInputJson, err := json.Marshal(rewardGetInput)
 	input := string(rewardGetInputJson)
+	network := block.Network.Resolve()
 
 	callData := model.CallContractRequest{
-		ContractAddress: "buQqzdS9YSnokDjvzg4YaNatcFQfkgXqk6ss",
+		ContractAddress: network.RewardContract,
 		Code:            "",
 		Input:           input,
 		ContractBalance: "",
-		FeeLimit:        100000000000,
-		GasPrice:        10000,
+		FeeLimit:        network.QueryFeeLimit,
+		GasPrice:        network.QueryGasPrice,
 		OptType:         2,
 		SourceAddress:   "",
 	}
@@ -395,6 +405,8 @@ func (block *BlockOperation) GetReward(reqData model.BlockGetRewardRequest) mode
 			resData.Result.Kols[i].Reward = value
 			i++
 		}
+		sortRewards(resData.Result.Validators)
+		sortRewards(resData.Result.Kols)
 
 		return resData;
 	} else {
@@ -412,14 +424,15 @@ func (block *BlockOperation) GetLatestReward() model.BlockGetLatestRewardRespons
 	rewardGetInput.Method = "getRewardDistribute";
 	rewardGetInputJson, err := json.Marshal(rewardGetInput)
 	input := string(rewardGetInputJson)
+	network := block.Network.Resolve()
 
 	callData := model.CallContractRequest{
-		ContractAddress: "buQqzdS9YSnokDjvzg4YaNatcFQfkgXqk6ss",
+		ContractAddress: network.RewardContract,
 		Code:            "",
 		Input:           input,
 		ContractBalance: "",
-		FeeLimit:        100000000000,
-		GasPrice:        10000,
+		FeeLimit:        network.QueryFeeLimit,
+		GasPrice:        network.QueryGasPrice,
 		OptType:         2,
 		SourceAddress:   "",
 	}
@@ -474,6 +487,8 @@ func (block *BlockOperation) GetLatestReward() model.BlockGetLatestRewardRespons
 			resData.Result.Kols[i].Reward = value
 			i++
 		}
+		sortRewards(resData.Result.Validators)
+		sortRewards(resData.Result.Kols)
 
 		return resData;
 	} else {
@@ -576,3 +591,241 @@ func (block *BlockOperation) GetLatestFees() model.BlockGetLatestFeesResponse {
 		return resData
 	}
 }
+
+// verify transactions
+// The ledger header carries no transaction tree hash, so the returned list is
+// checked against the tx_count growth of the headers. Each transaction is
+// serialized and hashed here rather than trusting the hash the node reports,
+// and the tree hash over those is compared with a trusted one when the caller
+// supplies it.
+func (block *BlockOperation) VerifyTransactions(reqData model.BlockVerifyTransactionsRequest) model.BlockVerifyTransactionsResponse {
+	var resData model.BlockVerifyTransactionsResponse
+	if reqData.GetBlockNumber() <= 0 {
+		SDKRes := exception.GetSDKRes(exception.INVALID_BLOCKNUMBER_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	var expected []byte
+	if reqData.GetTransactionTreeHash() != "" {
+		var err error
+		expected, err = hex.DecodeString(reqData.GetTransactionTreeHash())
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.INVALID_HASH_ERROR)
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+	}
+	var reqDataInfo model.BlockGetInfoRequest
+	reqDataInfo.SetBlockNumber(reqData.GetBlockNumber())
+	resDataInfo := block.GetInfo(reqDataInfo)
+	if resDataInfo.ErrorCode != 0 {
+		resData.ErrorCode = resDataInfo.ErrorCode
+		resData.ErrorDesc = resDataInfo.ErrorDesc
+		return resData
+	}
+	txCount := resDataInfo.Result.Header.TxCount
+	if reqData.GetBlockNumber() > 1 {
+		reqDataInfo.SetBlockNumber(reqData.GetBlockNumber() - 1)
+		resDataPrevious := block.GetInfo(reqDataInfo)
+		if resDataPrevious.ErrorCode != 0 {
+			resData.ErrorCode = resDataPrevious.ErrorCode
+			resData.ErrorDesc = resDataPrevious.ErrorDesc
+			return resData
+		}
+		txCount -= resDataPrevious.Result.Header.TxCount
+	}
+	transactions, SDKRes := block.ledgerTransactions(reqData.GetBlockNumber())
+	if SDKRes.ErrorCode != 0 && !(SDKRes.ErrorCode == 4 && txCount == 0) {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	envs := make([]*protocol.TransactionEnv, len(transactions))
+	resData.Result.IsValid = int64(len(transactions)) == txCount
+	for i := range transactions {
+		envs[i] = &protocol.TransactionEnv{Transaction: transactions[i].transaction}
+		hash, err := merkle.TransactionHash(transactions[i].transaction)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc + ": " + err.Error()
+			return resData
+		}
+		if hex.EncodeToString(hash) != transactions[i].hash || transactions[i].ledgerSeq != reqData.GetBlockNumber() {
+			resData.Result.IsValid = false
+		}
+	}
+	root, err := merkle.TransactionTreeHash(envs)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc + ": " + err.Error()
+		return resData
+	}
+	if expected != nil && !bytes.Equal(root, expected) {
+		resData.Result.IsValid = false
+	}
+	resData.Result.TxCount = txCount
+	resData.Result.TransactionTreeHash = hex.EncodeToString(root)
+	resData.ErrorCode = exception.SUCCESS
+	return resData
+}
+
+// ledgerTransaction is a transaction of a ledger decoded into its protocol
+// form, along with what the node says about it.
+type ledgerTransaction struct {
+	hash        string
+	ledgerSeq   int64
+	transaction *protocol.Transaction
+}
+
+// ledgerTransactions reads the transactions of a ledger the way
+// GetTransactions does, but keeps each transaction body so it can be hashed.
+func (block *BlockOperation) ledgerTransactions(blockNumber int64) ([]ledgerTransaction, exception.SDKResponse) {
+	bnstr := strconv.FormatInt(blockNumber, 10)
+	get := "/getTransactionHistory?ledger_seq="
+	response, SDKRes := common.GetRequest(block.Url, get, bnstr)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		return nil, exception.GetSDKRes(exception.CONNECTNETWORK_ERROR)
+	}
+	var resData struct {
+		ErrorCode int    `json:"error_code"`
+		ErrorDesc string `json:"error_desc"`
+		Result    struct {
+			Transactions []struct {
+				Hash        string          `json:"hash"`
+				LedgerSeq   int64           `json:"ledger_seq"`
+				Transaction json.RawMessage `json:"transaction"`
+			} `json:"transactions"`
+		} `json:"result"`
+	}
+	err := json.NewDecoder(response.Body).Decode(&resData)
+	if err != nil {
+		return nil, exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	if resData.ErrorCode != 0 {
+		if resData.ErrorCode == 4 {
+			resData.ErrorDesc = "Get transactions failed"
+		}
+		return nil, exception.SDKResponse{ErrorCode: resData.ErrorCode, ErrorDesc: resData.ErrorDesc}
+	}
+	transactions := make([]ledgerTransaction, len(resData.Result.Transactions))
+	for i, item := range resData.Result.Transactions {
+		transaction, err := decodeTransaction(item.Transaction)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+			SDKRes.ErrorDesc += ": " + item.Hash + ": " + err.Error()
+			return nil, SDKRes
+		}
+		transactions[i] = ledgerTransaction{hash: item.Hash, ledgerSeq: item.LedgerSeq, transaction: transaction}
+	}
+	return transactions, exception.SDKResponse{ErrorCode: exception.SUCCESS}
+}
+
+// decodeTransaction reads a transaction in the node's JSON form, which
+// writes bytes fields such as metadata in hex where protobuf JSON expects
+// base64. Fields the SDK does not know are dropped, so such a transaction
+// hashes differently from what the node reports.
+func decodeTransaction(data json.RawMessage) (*protocol.Transaction, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	err := decoder.Decode(&fields)
+	if err != nil {
+		return nil, err
+	}
+	err = hexToBase64(fields, "metadata")
+	if err != nil {
+		return nil, err
+	}
+	operations, _ := fields["operations"].([]interface{})
+	for _, operation := range operations {
+		operationFields, ok := operation.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		err = hexToBase64(operationFields, "metadata")
+		if err != nil {
+			return nil, err
+		}
+	}
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var transaction protocol.Transaction
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	err = unmarshaler.Unmarshal(bytes.NewReader(data), &transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// hexToBase64 rewrites a hex string field as base64 in place.
+func hexToBase64(fields map[string]interface{}, name string) error {
+	value, ok := fields[name].(string)
+	if !ok {
+		return nil
+	}
+	raw, err := hex.DecodeString(value)
+	if err != nil {
+		return err
+	}
+	fields[name] = base64.StdEncoding.EncodeToString(raw)
+	return nil
+}
+
+// get chain params
+// The result is what TransactionBuildBlobRequest.SetChainParams needs to build
+// a blob on a machine without access to the node.
+func (block *BlockOperation) GetChainParams() model.BlockGetChainParamsResponse {
+	var resData model.BlockGetChainParamsResponse
+	resDataNumber := block.GetNumber()
+	if resDataNumber.ErrorCode != 0 {
+		resData.ErrorCode = resDataNumber.ErrorCode
+		resData.ErrorDesc = resDataNumber.ErrorDesc
+		return resData
+	}
+	resDataFees := block.GetLatestFees()
+	if resDataFees.ErrorCode != 0 {
+		resData.ErrorCode = resDataFees.ErrorCode
+		resData.ErrorDesc = resDataFees.ErrorDesc
+		return resData
+	}
+	resData.Result.GasPrice = resDataFees.Result.Fees.GasPrice
+	resData.Result.BaseReserve = resDataFees.Result.Fees.BaseReserve
+	resData.Result.LedgerSeq = resDataNumber.Result.Header.BlockNumber
+	var reqDataInfo model.BlockGetInfoRequest
+	reqDataInfo.SetBlockNumber(resData.Result.LedgerSeq)
+	resDataLatest := block.GetInfo(reqDataInfo)
+	if resDataLatest.ErrorCode != 0 {
+		resData.ErrorCode = resDataLatest.ErrorCode
+		resData.ErrorDesc = resDataLatest.ErrorDesc
+		return resData
+	}
+	resData.Result.CloseTime = resDataLatest.Result.Header.CloseTime
+	// the average interval over the last closeIntervalWindow ledgers
+	first := resData.Result.LedgerSeq - closeIntervalWindow
+	if first < 1 {
+		first = 1
+	}
+	if first < resData.Result.LedgerSeq {
+		reqDataInfo.SetBlockNumber(first)
+		resDataFirst := block.GetInfo(reqDataInfo)
+		if resDataFirst.ErrorCode != 0 {
+			resData.ErrorCode = resDataFirst.ErrorCode
+			resData.ErrorDesc = resDataFirst.ErrorDesc
+			return resData
+		}
+		resData.Result.CloseInterval = (resData.Result.CloseTime - resDataFirst.Result.Header.CloseTime) / (resData.Result.LedgerSeq - first)
+	}
+	resData.ErrorCode = exception.SUCCESS
+	return resData
+}
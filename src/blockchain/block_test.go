@@ -0,0 +1,111 @@
+// block_test
+package blockchain_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/merkle"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+func Test_GetRewardNetwork(t *testing.T) {
+	var call model.CallContractRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &call)
+		w.Write([]byte(`{"error_code":0,"result":{"query_rets":[{"result":{"value":"{\"rewards\":{\"validators\":{\"buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo\":[\"100\",\"50\"]},\"kols\":{}}}"}}]}}`))
+	}))
+	defer server.Close()
+
+	// the zero network is the main network
+	block := blockchain.BlockOperation{Url: server.URL}
+	resData := block.GetLatestReward()
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	mainnet := model.MainnetNetwork()
+	if call.ContractAddress != mainnet.RewardContract || call.FeeLimit != mainnet.QueryFeeLimit || call.GasPrice != mainnet.QueryGasPrice {
+		t.Errorf("wrong call %+v", call)
+	}
+	if len(resData.Result.Validators) != 1 || len(resData.Result.Validators[0].Reward) != 2 {
+		t.Errorf("wrong rewards %+v", resData.Result)
+	}
+
+	block.Network = model.Network{RewardContract: "buQhdBSkJqERBSsYiUShUZFMZQhXvkdNgnYq", QueryGasPrice: 1000}
+	var reqData model.BlockGetRewardRequest
+	reqData.SetBlockNumber(5)
+	if resData := block.GetReward(reqData); resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	if call.ContractAddress != "buQhdBSkJqERBSsYiUShUZFMZQhXvkdNgnYq" || call.GasPrice != 1000 || call.FeeLimit != mainnet.QueryFeeLimit {
+		t.Errorf("network is not used %+v", call)
+	}
+}
+
+func Test_VerifyTransactions(t *testing.T) {
+	transaction := &protocol.Transaction{
+		SourceAddress: "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo",
+		Nonce:         3,
+		FeeLimit:      1000000,
+		GasPrice:      1000,
+		Metadata:      []byte("pay"),
+		Operations: []*protocol.Operation{{
+			Type:     protocol.Operation_PAY_COIN,
+			Metadata: []byte("op"),
+			PayCoin:  &protocol.OperationPayCoin{DestAddress: "buQhdBSkJqERBSsYiUShUZFMZQhXvkdNgnYq", Amount: 500},
+		}},
+	}
+	txHash, err := merkle.TransactionHash(transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := hex.EncodeToString(txHash)
+	// the node writes bytes in hex and enums as numbers
+	body := `{"source_address":"buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo","nonce":3,"fee_limit":1000000,"gas_price":1000,"metadata":"%s","operations":[{"type":7,"metadata":"%s","pay_coin":{"dest_address":"buQhdBSkJqERBSsYiUShUZFMZQhXvkdNgnYq","amount":%d}}]}`
+	var amount int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getLedger":
+			txCount := 4
+			if r.URL.Query().Get("seq") == "9" {
+				txCount = 5
+			}
+			fmt.Fprintf(w, `{"error_code":0,"result":{"header":{"seq":%s,"tx_count":%d}}}`, r.URL.Query().Get("seq"), txCount)
+		case "/getTransactionHistory":
+			tx := fmt.Sprintf(body, hex.EncodeToString([]byte("pay")), hex.EncodeToString([]byte("op")), amount)
+			fmt.Fprintf(w, `{"error_code":0,"result":{"total_count":1,"transactions":[{"hash":"%s","ledger_seq":9,"transaction":%s}]}}`, hash, tx)
+		}
+	}))
+	defer server.Close()
+
+	block := blockchain.BlockOperation{Url: server.URL}
+	var reqData model.BlockVerifyTransactionsRequest
+	reqData.SetBlockNumber(9)
+	reqData.SetTransactionTreeHash(hash)
+	amount = 500
+	resData := block.VerifyTransactions(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	if !resData.Result.IsValid || resData.Result.TxCount != 1 || resData.Result.TransactionTreeHash != hash {
+		t.Errorf("wrong result %+v", resData.Result)
+	}
+
+	// a body that does not match the reported hash is caught
+	amount = 5000
+	resData = block.VerifyTransactions(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	if resData.Result.IsValid || resData.Result.TransactionTreeHash == hash {
+		t.Errorf("tampered transaction is valid %+v", resData.Result)
+	}
+}
@@ -0,0 +1,353 @@
+// exporter
+package blockchain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// Record kinds of the export
+const (
+	RECORD_LEDGER      = "ledger"
+	RECORD_TRANSACTION = "transaction"
+	RECORD_OPERATION   = "operation"
+	RECORD_REWARD      = "reward"
+)
+
+// ExportSchema lists the columns of every record kind. Columns are only ever
+// appended, so readers of older exports keep working.
+var ExportSchema = map[string][]string{
+	RECORD_LEDGER: {"seq", "hash", "previous_hash", "close_time", "tx_count", "version",
+		"account_tree_hash", "consensus_value_hash", "validators_hash", "fees_hash", "gas_price", "base_reserve"},
+	RECORD_TRANSACTION: {"ledger_seq", "hash", "source_address", "nonce", "fee_limit", "gas_price",
+		"actual_fee", "error_code", "error_desc", "close_time", "tx_size", "metadata", "operation_count"},
+	RECORD_OPERATION: {"ledger_seq", "tx_hash", "index", "type", "source_address", "dest_address",
+		"amount", "asset_code", "asset_issuer", "metadata"},
+	RECORD_REWARD: {"ledger_seq", "role", "address", "reward"},
+}
+
+// ExportRecord holds the values of one record in the column order of ExportSchema.
+type ExportRecord struct {
+	Kind   string
+	Values []interface{}
+}
+
+// ExportWriter writes records. Flush is called after every ledger, before the
+// checkpoint is saved.
+type ExportWriter interface {
+	Write(record ExportRecord) error
+	Flush() error
+}
+
+// CSVExportWriter writes every record kind to its own CSV stream, with a header
+// line unless the stream is resumed.
+type CSVExportWriter struct {
+	writers map[string]*csv.Writer
+	header  map[string]bool
+}
+
+// NewCSVExportWriter takes one writer per record kind; kinds without a writer
+// are skipped. With resume set, no header lines are written.
+func NewCSVExportWriter(writers map[string]io.Writer, resume bool) *CSVExportWriter {
+	exportWriter := &CSVExportWriter{writers: make(map[string]*csv.Writer), header: make(map[string]bool)}
+	for kind, writer := range writers {
+		exportWriter.writers[kind] = csv.NewWriter(writer)
+		exportWriter.header[kind] = resume
+	}
+	return exportWriter
+}
+
+// Write
+func (exportWriter *CSVExportWriter) Write(record ExportRecord) error {
+	writer, ok := exportWriter.writers[record.Kind]
+	if !ok {
+		return nil
+	}
+	if len(ExportSchema[record.Kind]) != len(record.Values) {
+		return errors.New("record does not match the schema: " + record.Kind)
+	}
+	if !exportWriter.header[record.Kind] {
+		err := writer.Write(ExportSchema[record.Kind])
+		if err != nil {
+			return err
+		}
+		exportWriter.header[record.Kind] = true
+	}
+	line := make([]string, len(record.Values))
+	for i := range record.Values {
+		line[i] = fmt.Sprint(record.Values[i])
+	}
+	return writer.Write(line)
+}
+
+// Flush
+func (exportWriter *CSVExportWriter) Flush() error {
+	for _, writer := range exportWriter.writers {
+		writer.Flush()
+		err := writer.Error()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JSONLinesExportWriter writes every record as one JSON object per line, with a
+// "kind" member followed by the columns in schema order.
+type JSONLinesExportWriter struct {
+	writer io.Writer
+	buf    bytes.Buffer
+}
+
+// NewJSONLinesExportWriter
+func NewJSONLinesExportWriter(writer io.Writer) *JSONLinesExportWriter {
+	return &JSONLinesExportWriter{writer: writer}
+}
+
+// Write
+func (exportWriter *JSONLinesExportWriter) Write(record ExportRecord) error {
+	columns, ok := ExportSchema[record.Kind]
+	if !ok || len(columns) != len(record.Values) {
+		return errors.New("record does not match the schema: " + record.Kind)
+	}
+	var line bytes.Buffer
+	kind, _ := json.Marshal(record.Kind)
+	line.WriteString(`{"kind":`)
+	line.Write(kind)
+	for i := range columns {
+		value, err := json.Marshal(record.Values[i])
+		if err != nil {
+			return err
+		}
+		line.WriteString(`,"` + columns[i] + `":`)
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	exportWriter.buf.Write(line.Bytes())
+	return nil
+}
+
+// Flush
+func (exportWriter *JSONLinesExportWriter) Flush() error {
+	_, err := exportWriter.buf.WriteTo(exportWriter.writer)
+	return err
+}
+
+// ExportCheckpoint keeps the next ledger to export, so a stopped export can be
+// resumed. Load returns 0 when nothing is saved yet.
+type ExportCheckpoint interface {
+	Load() (int64, error)
+	Save(next int64) error
+}
+
+// FileCheckpoint keeps the checkpoint in a text file, replaced atomically.
+type FileCheckpoint struct {
+	Path string
+}
+
+// Load
+func (checkpoint *FileCheckpoint) Load() (int64, error) {
+	data, err := ioutil.ReadFile(checkpoint.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// Save
+func (checkpoint *FileCheckpoint) Save(next int64) error {
+	file, err := ioutil.TempFile(filepath.Dir(checkpoint.Path), filepath.Base(checkpoint.Path))
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(strconv.FormatInt(next, 10))
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), checkpoint.Path)
+}
+
+// LedgerExporter streams ledger headers with their fees, transactions and
+// operations of a ledger range to an ExportWriter.
+//
+// The node only reports the current reward distribution, so with Rewards set
+// it is exported once per Export call that reaches the latest ledger, labelled
+// with the number of the latest ledger read before the distribution.
+//
+// Delivery is at least once: the checkpoint is saved after the writer is
+// flushed, so a stop between the two writes that ledger again on resume.
+// Readers drop the repeated rows by ledger_seq, keeping the last copy.
+type LedgerExporter struct {
+	Url        string
+	Network    model.Network
+	Writer     ExportWriter
+	Checkpoint ExportCheckpoint
+	Rewards    bool
+}
+
+// Export writes the ledgers from start to end, both included; an end of 0
+// exports up to the latest ledger. When the checkpoint is ahead of start, the
+// export resumes from the checkpoint. The returned sequence is the next ledger
+// to export.
+func (exporter *LedgerExporter) Export(start int64, end int64) (int64, exception.SDKResponse) {
+	if exporter.Writer == nil {
+		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+		SDKRes.ErrorDesc = "Export writer is nil"
+		return start, SDKRes
+	}
+	if exporter.Checkpoint != nil {
+		next, err := exporter.Checkpoint.Load()
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+			SDKRes.ErrorDesc = err.Error()
+			return start, SDKRes
+		}
+		if next > start {
+			start = next
+		}
+		if end != 0 && start > end {
+			return start, exception.GetSDKRes(exception.SUCCESS)
+		}
+	}
+	block := BlockOperation{Url: exporter.Url, Network: exporter.Network}
+	scanner := LedgerScanner{Url: exporter.Url, Network: exporter.Network}
+	next, SDKRes := scanner.Scan(start, end, func(header model.GetInfoHeader, transactions []model.Transactioninfo) error {
+		var reqDataFees model.BlockGetFeesRequest
+		reqDataFees.SetBlockNumber(header.Number)
+		resDataFees := block.GetFees(reqDataFees)
+		if resDataFees.ErrorCode != 0 {
+			return errors.New(resDataFees.ErrorDesc)
+		}
+		for _, record := range ExportRecords(header, resDataFees.Result.Fees, transactions) {
+			err := exporter.Writer.Write(record)
+			if err != nil {
+				return err
+			}
+		}
+		return exporter.commit(header.Number + 1)
+	})
+	if SDKRes.ErrorCode != 0 || !exporter.Rewards || next == start {
+		return next, SDKRes
+	}
+	// a range that ends before the latest ledger has no distribution to export
+	resDataNumber := block.GetNumber()
+	if resDataNumber.ErrorCode != 0 {
+		return next, exception.SDKResponse{ErrorCode: resDataNumber.ErrorCode, ErrorDesc: resDataNumber.ErrorDesc}
+	}
+	latest := resDataNumber.Result.Header.BlockNumber
+	if latest != next-1 {
+		return next, exception.GetSDKRes(exception.SUCCESS)
+	}
+	var reqDataReward model.BlockGetRewardRequest
+	reqDataReward.SetBlockNumber(latest)
+	resDataReward := block.GetReward(reqDataReward)
+	if resDataReward.ErrorCode != 0 {
+		return next, exception.SDKResponse{ErrorCode: resDataReward.ErrorCode, ErrorDesc: resDataReward.ErrorDesc}
+	}
+	for _, record := range RewardRecords(latest, resDataReward.Result) {
+		err := exporter.Writer.Write(record)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+			SDKRes.ErrorDesc = err.Error()
+			return next, SDKRes
+		}
+	}
+	err := exporter.Writer.Flush()
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+		SDKRes.ErrorDesc = err.Error()
+		return next, SDKRes
+	}
+	return next, exception.GetSDKRes(exception.SUCCESS)
+}
+
+func (exporter *LedgerExporter) commit(next int64) error {
+	err := exporter.Writer.Flush()
+	if err != nil || exporter.Checkpoint == nil {
+		return err
+	}
+	return exporter.Checkpoint.Save(next)
+}
+
+// ExportRecords turns one ledger into its ledger, transaction and operation records.
+func ExportRecords(header model.GetInfoHeader, fees model.Fees, transactions []model.Transactioninfo) []ExportRecord {
+	records := []ExportRecord{{Kind: RECORD_LEDGER, Values: []interface{}{
+		header.Number, header.Hash, header.PreviousHash, header.CloseTime, header.TxCount, header.Version,
+		header.AccountTreeHash, header.ConsensusValueHash, header.ValidatorsHash, header.FeesHash,
+		fees.GasPrice, fees.BaseReserve,
+	}}}
+	for _, transaction := range transactions {
+		tx := transaction.Transaction
+		records = append(records, ExportRecord{Kind: RECORD_TRANSACTION, Values: []interface{}{
+			header.Number, transaction.Hash, tx.SourceAddress, tx.Nonce, tx.FeeLimit, tx.GasPrice,
+			transaction.ActualFee, transaction.ErrorCode, transaction.ErrorDesc, transaction.CloseTime,
+			transaction.TxSize, tx.Metadata, len(tx.Operations),
+		}})
+		for i, operation := range tx.Operations {
+			source := operation.SourceAddress
+			if source == "" {
+				source = tx.SourceAddress
+			}
+			var dest, code, issuer string
+			var amount int64
+			switch protocol.Operation_Type(operation.Type) {
+			case protocol.Operation_CREATE_ACCOUNT:
+				dest, amount = operation.CreateAccount.DestAddress, operation.CreateAccount.InitBalance
+			case protocol.Operation_PAY_ASSET:
+				dest, amount = operation.PayAsset.DestAddress, operation.PayAsset.Asset.Amount
+				code, issuer = operation.PayAsset.Asset.Key.Code, operation.PayAsset.Asset.Key.Issuer
+			case protocol.Operation_PAY_COIN:
+				dest, amount = operation.PayCoin.DestAddress, operation.PayCoin.Amount
+			}
+			records = append(records, ExportRecord{Kind: RECORD_OPERATION, Values: []interface{}{
+				header.Number, transaction.Hash, i, operation.Type, source, dest, amount, code, issuer, operation.Metadata,
+			}})
+		}
+	}
+	return records
+}
+
+// RewardRecords turns a reward distribution into reward records ordered by
+// address, the reward column holding the JSON of the distribution entry.
+func RewardRecords(ledgerSeq int64, rewards model.BlockGetRewardResult) []ExportRecord {
+	var records []ExportRecord
+	roles := []struct {
+		role    string
+		rewards []model.Rewards
+	}{{"validator", rewards.Validators}, {"kol", rewards.Kols}}
+	for _, role := range roles {
+		sort.Slice(role.rewards, func(i, j int) bool { return role.rewards[i].Address < role.rewards[j].Address })
+		for _, reward := range role.rewards {
+			value, _ := json.Marshal(reward.Reward)
+			records = append(records, ExportRecord{Kind: RECORD_REWARD, Values: []interface{}{
+				ledgerSeq, role.role, reward.Address, string(value),
+			}})
+		}
+	}
+	return records
+}
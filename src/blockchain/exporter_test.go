@@ -0,0 +1,145 @@
+// exporter_test
+package blockchain_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+func exportLedger() []blockchain.ExportRecord {
+	var transaction model.Transactioninfo
+	transaction.Hash = "ab"
+	transaction.ActualFee = 1000
+	transaction.Transaction.SourceAddress = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+	transaction.Transaction.Nonce = 3
+	transaction.Transaction.Operations = []model.Operation{
+		{Type: 7, PayCoin: model.PayCoin{DestAddress: "buQBjJD1BSJ7nzAbzdTenAhpFjmxRVEEtmxH", Amount: 500}},
+		{Type: 4, Metadata: "note"},
+	}
+	header := model.GetInfoHeader{Number: 9, Hash: "cd", CloseTime: 77, TxCount: 12}
+	return blockchain.ExportRecords(header, model.Fees{GasPrice: 1000, BaseReserve: 10000000}, []model.Transactioninfo{transaction})
+}
+
+// every record matches the schema of its kind
+func Test_ExportRecords(t *testing.T) {
+	records := exportLedger()
+	kinds := []string{"ledger", "transaction", "operation", "operation"}
+	if len(records) != len(kinds) {
+		t.Fatalf("got %d records, want %d", len(records), len(kinds))
+	}
+	for i := range records {
+		if records[i].Kind != kinds[i] || len(records[i].Values) != len(blockchain.ExportSchema[kinds[i]]) {
+			t.Errorf("record %d does not match the schema: %+v", i, records[i])
+		}
+	}
+}
+
+func Test_JSONLinesExportWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := blockchain.NewJSONLinesExportWriter(&out)
+	for _, record := range exportLedger()[2:3] {
+		writer.Write(record)
+	}
+	if out.Len() != 0 {
+		t.Error("records are written before the flush")
+	}
+	writer.Flush()
+	expected := `{"kind":"operation","ledger_seq":9,"tx_hash":"ab","index":0,"type":7,` +
+		`"source_address":"buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo","dest_address":"buQBjJD1BSJ7nzAbzdTenAhpFjmxRVEEtmxH",` +
+		`"amount":500,"asset_code":"","asset_issuer":"","metadata":""}` + "\n"
+	if out.String() != expected {
+		t.Errorf("got %s", out.String())
+	}
+}
+
+// a resumed stream gets no second header
+func Test_CSVExportWriter(t *testing.T) {
+	var ledgers bytes.Buffer
+	writer := blockchain.NewCSVExportWriter(map[string]io.Writer{"ledger": &ledgers}, false)
+	for _, record := range exportLedger() {
+		writer.Write(record)
+	}
+	writer.Flush()
+	expected := "seq,hash,previous_hash,close_time,tx_count,version,account_tree_hash,consensus_value_hash,validators_hash,fees_hash,gas_price,base_reserve\n" +
+		"9,cd,,77,12,0,,,,,1000,10000000\n"
+	if ledgers.String() != expected {
+		t.Errorf("got %s", ledgers.String())
+	}
+	ledgers.Reset()
+	writer = blockchain.NewCSVExportWriter(map[string]io.Writer{"ledger": &ledgers}, true)
+	writer.Write(exportLedger()[0])
+	writer.Flush()
+	if ledgers.String() != "9,cd,,77,12,0,,,,,1000,10000000\n" {
+		t.Errorf("resumed stream got %s", ledgers.String())
+	}
+	err := writer.Write(blockchain.ExportRecord{Kind: blockchain.RECORD_LEDGER, Values: []interface{}{9, "cd"}})
+	if err == nil {
+		t.Error("record with missing columns is written")
+	}
+}
+
+// the distribution is only exported, at the latest ledger, when the range
+// reaches it
+func Test_ExportRewards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getLedger":
+			seq := r.URL.Query().Get("seq")
+			if seq == "" {
+				seq = "5"
+			}
+			w.Write([]byte(`{"error_code":0,"result":{"header":{"seq":` + seq + `},"fees":{"gas_price":1000,"base_reserve":10000000}}}`))
+		case "/getTransactionHistory":
+			w.Write([]byte(`{"error_code":4}`))
+		case "/callContract":
+			w.Write([]byte(`{"error_code":0,"result":{"query_rets":[{"result":{"value":"{\"rewards\":{\"validators\":{\"buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo\":[\"100\",\"50\"]},\"kols\":{}}}"}}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	exporter := blockchain.LedgerExporter{Url: server.URL, Writer: blockchain.NewJSONLinesExportWriter(&out), Rewards: true}
+	next, SDKRes := exporter.Export(2, 3)
+	if SDKRes.ErrorCode != 0 || next != 4 {
+		t.Fatal(next, SDKRes.ErrorDesc)
+	}
+	if strings.Contains(out.String(), `"kind":"reward"`) {
+		t.Errorf("current rewards exported for a past range: %s", out.String())
+	}
+	out.Reset()
+	next, SDKRes = exporter.Export(4, 0)
+	if SDKRes.ErrorCode != 0 || next != 6 {
+		t.Fatal(next, SDKRes.ErrorDesc)
+	}
+	if !strings.Contains(out.String(), `{"kind":"reward","ledger_seq":5,"role":"validator","address":"buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"`) {
+		t.Errorf("rewards are not exported at the latest ledger: %s", out.String())
+	}
+}
+
+func Test_FileCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	checkpoint := blockchain.FileCheckpoint{Path: filepath.Join(dir, "export.checkpoint")}
+	next, err := checkpoint.Load()
+	if err != nil || next != 0 {
+		t.Error("missing checkpoint is not 0:", next, err)
+	}
+	checkpoint.Save(581284)
+	next, err = checkpoint.Load()
+	if err != nil || next != 581284 {
+		t.Error("checkpoint is not restored:", next, err)
+	}
+}
@@ -0,0 +1,233 @@
+// fee
+package blockchain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bumoproject/bumo-sdk-go/src/common"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/golang/protobuf/proto"
+)
+
+// placeholders with the serialized size of a real signature
+var (
+	placeholderPublicKey = strings.Repeat("0", 76)
+	placeholderSignData  = make([]byte, 64)
+)
+
+// TransactionFee is the fee of a transaction that invokes no contract: the gas
+// price times the serialized size of the transaction envelope with
+// signatureNumber signatures. The gas price and fee limit of the transaction
+// are set to the returned fee, as they count in the size.
+func TransactionFee(transaction *protocol.Transaction, signatureNumber int64, gasPrice int64) int64 {
+	env := protocol.TransactionEnv{Transaction: transaction}
+	for i := int64(0); i < signatureNumber; i++ {
+		env.Signatures = append(env.Signatures, &protocol.Signature{PublicKey: placeholderPublicKey, SignData: placeholderSignData})
+	}
+	transaction.GasPrice = gasPrice
+	// the fee limit is part of the size, so iterate until it covers itself
+	var fee int64
+	for i := 0; i < 4; i++ {
+		transaction.FeeLimit = fee
+		size := int64(proto.Size(&env))
+		if size*gasPrice == fee {
+			break
+		}
+		fee = size * gasPrice
+	}
+	transaction.FeeLimit = fee
+	return fee
+}
+
+// signedSizeFee is the fee of the transaction, as it is, with one signature:
+// the least fee limit it can have at its gas price.
+func signedSizeFee(transaction *protocol.Transaction) int64 {
+	env := protocol.TransactionEnv{
+		Transaction: transaction,
+		Signatures:  []*protocol.Signature{{PublicKey: placeholderPublicKey, SignData: placeholderSignData}},
+	}
+	return int64(proto.Size(&env)) * transaction.GasPrice
+}
+
+// FeeEstimator prices transactions locally from the fee config of the chain,
+// which is fetched once when FeeConfig is empty. Transactions that invoke a
+// contract use gas beyond their size and are evaluated by the node, as are
+// asset issues, which the chain charges a fixed fee beyond their size. Payment
+// destinations are looked up, once each, so that payments to contract accounts
+// are evaluated by the node as well. With SkipContractCheck set, only payments
+// with an input count as contract invocations, which underprices payments
+// that trigger a contract.
+type FeeEstimator struct {
+	Url               string
+	Network           model.Network
+	FeeConfig         protocol.FeeConfig
+	SkipContractCheck bool
+
+	lock      sync.Mutex
+	contracts map[string]bool
+}
+
+// EstimateFee
+func (estimator *FeeEstimator) EstimateFee(reqData model.TransactionEvaluateFeeRequest) model.TransactionEvaluateFeeResponse {
+	var resData model.TransactionEvaluateFeeResponse
+	if !estimator.Network.CheckAddress(reqData.GetSourceAddress()) {
+		SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if reqData.GetNonce() <= 0 {
+		SDKRes := exception.GetSDKRes(exception.INVALID_NONCE_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	operationsData := reqData.GetOperations()
+	if operationsData.Len() == 0 {
+		SDKRes := exception.GetSDKRes(exception.OPERATIONS_EMPTY_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if reqData.GetCeilLedgerSeq() < 0 {
+		SDKRes := exception.GetSDKRes(exception.INVALID_CEILLEDGERSEQ_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	var signatureNumber int64 = 1
+	if len(reqData.GetSignatureNumber()) != 0 {
+		var err error
+		signatureNumber, err = strconv.ParseInt(reqData.GetSignatureNumber(), 10, 64)
+		if err != nil || signatureNumber <= 0 || signatureNumber > math.MaxInt32 {
+			SDKRes := exception.GetSDKRes(exception.INVALID_SIGNATURENUMBER_ERROR)
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+	}
+	operations, SDKRes := common.GetOperations(operationsData, estimator.Url, reqData.GetSourceAddress(), estimator.Network)
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	invokes, SDKRes := estimator.invokesContract(operations)
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if invokes {
+		transaction := TransactionOperation{Url: estimator.Url, Network: estimator.Network}
+		return transaction.EvaluateFee(reqData)
+	}
+	SDKRes = estimator.loadFeeConfig()
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	var ceilLedgerSeq int64
+	if reqData.GetCeilLedgerSeq() > 0 {
+		// the height is not known offline, so count the widest varint it can take
+		ceilLedgerSeq = math.MaxInt64
+	}
+	transaction := protocol.Transaction{
+		SourceAddress: reqData.GetSourceAddress(),
+		Nonce:         reqData.GetNonce(),
+		CeilLedgerSeq: ceilLedgerSeq,
+		Metadata:      []byte(reqData.GetMetadata()),
+		Operations:    operations,
+	}
+	resData.Result.FeeLimit = TransactionFee(&transaction, signatureNumber, estimator.FeeConfig.GasPrice)
+	resData.Result.GasPrice = estimator.FeeConfig.GasPrice
+	resData.ErrorCode = exception.SUCCESS
+	return resData
+}
+
+// EstimateFees prices a batch of transactions, fetching the fee config and
+// looking up each destination only once.
+func (estimator *FeeEstimator) EstimateFees(reqDatas []model.TransactionEvaluateFeeRequest) []model.TransactionEvaluateFeeResponse {
+	resDatas := make([]model.TransactionEvaluateFeeResponse, len(reqDatas))
+	for i := range reqDatas {
+		resDatas[i] = estimator.EstimateFee(reqDatas[i])
+	}
+	return resDatas
+}
+
+func (estimator *FeeEstimator) loadFeeConfig() exception.SDKResponse {
+	estimator.lock.Lock()
+	defer estimator.lock.Unlock()
+	if estimator.FeeConfig.GasPrice != 0 {
+		return exception.GetSDKRes(exception.SUCCESS)
+	}
+	block := BlockOperation{Url: estimator.Url, Network: estimator.Network}
+	resDataFees := block.GetLatestFees()
+	if resDataFees.ErrorCode != 0 {
+		return exception.SDKResponse{ErrorCode: resDataFees.ErrorCode, ErrorDesc: resDataFees.ErrorDesc}
+	}
+	estimator.FeeConfig.GasPrice = resDataFees.Result.Fees.GasPrice
+	estimator.FeeConfig.BaseReserve = resDataFees.Result.Fees.BaseReserve
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+func (estimator *FeeEstimator) invokesContract(operations []*protocol.Operation) (bool, exception.SDKResponse) {
+	for _, operation := range operations {
+		var dest string
+		switch operation.GetType() {
+		case protocol.Operation_ISSUE_ASSET:
+			return true, exception.GetSDKRes(exception.SUCCESS)
+		case protocol.Operation_CREATE_ACCOUNT:
+			if operation.GetCreateAccount().GetContract().GetPayload() != "" {
+				return true, exception.GetSDKRes(exception.SUCCESS)
+			}
+			continue
+		case protocol.Operation_PAY_COIN:
+			if operation.GetPayCoin().GetInput() != "" {
+				return true, exception.GetSDKRes(exception.SUCCESS)
+			}
+			dest = operation.GetPayCoin().GetDestAddress()
+		case protocol.Operation_PAY_ASSET:
+			if operation.GetPayAsset().GetInput() != "" {
+				return true, exception.GetSDKRes(exception.SUCCESS)
+			}
+			dest = operation.GetPayAsset().GetDestAddress()
+		default:
+			continue
+		}
+		if estimator.SkipContractCheck {
+			continue
+		}
+		isContract, SDKRes := estimator.isContract(dest)
+		if SDKRes.ErrorCode != 0 || isContract {
+			return isContract, SDKRes
+		}
+	}
+	return false, exception.GetSDKRes(exception.SUCCESS)
+}
+
+func (estimator *FeeEstimator) isContract(address string) (bool, exception.SDKResponse) {
+	estimator.lock.Lock()
+	isContract, ok := estimator.contracts[address]
+	estimator.lock.Unlock()
+	if ok {
+		return isContract, exception.GetSDKRes(exception.SUCCESS)
+	}
+	isContract, SDKRes := common.CheckContract(address, estimator.Url, estimator.Network)
+	if SDKRes.ErrorCode != 0 {
+		return false, SDKRes
+	}
+	estimator.lock.Lock()
+	if estimator.contracts == nil {
+		estimator.contracts = make(map[string]bool)
+	}
+	estimator.contracts[address] = isContract
+	estimator.lock.Unlock()
+	return isContract, SDKRes
+}
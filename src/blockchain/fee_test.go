@@ -0,0 +1,123 @@
+// fee_test
+package blockchain_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/golang/protobuf/proto"
+)
+
+func feeRequest(dest string, input string) model.TransactionEvaluateFeeRequest {
+	var send model.BUSendOperation
+	send.Init()
+	send.SetDestAddress(dest)
+	send.SetAmount(100000)
+	var reqData model.TransactionEvaluateFeeRequest
+	reqData.SetSourceAddress(sourceAddress)
+	reqData.SetNonce(8)
+	reqData.SetOperation(send)
+	if input != "" {
+		var invoke model.ContractInvokeByBUOperation
+		invoke.Init()
+		invoke.SetContractAddress(dest)
+		invoke.SetInput(input)
+		reqData.SetOperation(invoke)
+	}
+	return reqData
+}
+
+// a node where carol is a contract account, which evaluates every
+// transaction at a fee of 5000000, and counts the account lookups and
+// evaluations
+func feeNode(lookups *int, evaluations *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getAccount":
+			*lookups++
+			address := r.URL.Query().Get("address")
+			payload := ""
+			if address == carol {
+				payload = "function main(input){}"
+			}
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + address + `","contract":{"payload":"` + payload + `"}}}`))
+		case "/testTransaction":
+			*evaluations++
+			w.Write([]byte(`{"error_code":0,"result":{"txs":[{"transaction_env":{"transaction":{"fee_limit":5000000,"gas_price":1000}}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// the fee covers the envelope including the fee limit itself
+func Test_TransactionFee(t *testing.T) {
+	transaction := protocol.Transaction{SourceAddress: sourceAddress, Nonce: 1}
+	fee := blockchain.TransactionFee(&transaction, 2, 1000)
+	env := protocol.TransactionEnv{Transaction: &transaction}
+	for i := 0; i < 2; i++ {
+		env.Signatures = append(env.Signatures, &protocol.Signature{PublicKey: string(make([]byte, 76)), SignData: make([]byte, 64)})
+	}
+	if transaction.FeeLimit != fee || int64(proto.Size(&env))*1000 != fee {
+		t.Errorf("fee %d does not match the envelope size %d", fee, proto.Size(&env))
+	}
+	if blockchain.TransactionFee(&transaction, 3, 1000) <= fee {
+		t.Error("a signature more costs nothing")
+	}
+}
+
+// payments are priced from the fee config, unless they go to a contract
+func Test_FeeEstimator(t *testing.T) {
+	var lookups, evaluations int
+	server := feeNode(&lookups, &evaluations)
+	defer server.Close()
+	estimator := blockchain.FeeEstimator{Url: server.URL, FeeConfig: protocol.FeeConfig{GasPrice: 1000}}
+	resData := estimator.EstimateFee(feeRequest(destAddress, ""))
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	if resData.Result.GasPrice != 1000 || resData.Result.FeeLimit <= 0 || resData.Result.FeeLimit%1000 != 0 || evaluations != 0 {
+		t.Errorf("wrong estimate %+v", resData.Result)
+	}
+	// the destination is looked up once
+	estimator.EstimateFee(feeRequest(destAddress, ""))
+	if lookups != 1 {
+		t.Errorf("%d lookups", lookups)
+	}
+
+	// a bare payment to a contract account goes to the node, as does an invocation
+	resData = estimator.EstimateFee(feeRequest(carol, ""))
+	if resData.ErrorCode != 0 || resData.Result.FeeLimit != 5000000 || evaluations != 1 {
+		t.Errorf("payment to a contract is priced locally %+v", resData.Result)
+	}
+	resData = estimator.EstimateFee(feeRequest(destAddress, `{"method":"transfer"}`))
+	if resData.ErrorCode != 0 || resData.Result.FeeLimit != 5000000 || evaluations != 2 {
+		t.Errorf("contract invocation is priced locally %+v", resData.Result)
+	}
+
+	// an asset issue has a fixed fee, which the node knows
+	var issue model.AssetIssueOperation
+	issue.Init()
+	issue.SetCode("CNY")
+	issue.SetAmount(1000)
+	var reqData model.TransactionEvaluateFeeRequest
+	reqData.SetSourceAddress(sourceAddress)
+	reqData.SetNonce(8)
+	reqData.SetOperation(issue)
+	resData = estimator.EstimateFee(reqData)
+	if resData.ErrorCode != 0 || resData.Result.FeeLimit != 5000000 || evaluations != 3 {
+		t.Errorf("asset issue is priced locally %+v", resData.Result)
+	}
+
+	// without the lookup, only invocations go to the node
+	estimator = blockchain.FeeEstimator{Url: server.URL, FeeConfig: protocol.FeeConfig{GasPrice: 1000}, SkipContractCheck: true}
+	lookups = 0
+	resData = estimator.EstimateFee(feeRequest(carol, ""))
+	if resData.ErrorCode != 0 || resData.Result.FeeLimit == 5000000 || lookups != 0 || evaluations != 3 {
+		t.Errorf("destination is looked up %+v", resData.Result)
+	}
+}
@@ -0,0 +1,200 @@
+// gasprice
+package blockchain
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const defaultGasPriceWindow int64 = 20
+
+// GasPriceStats summarizes the recent ledgers seen by a GasPriceOracle.
+type GasPriceStats struct {
+	LedgerSeq int64
+	// minimum gas price of the latest ledger
+	MinGasPrice int64
+	// gas prices paid by the transactions of the window
+	MedianGasPrice int64
+	FastGasPrice   int64
+	// transactions per ledger over the window
+	AverageTxCount float64
+}
+
+// FeeStrategy turns the recent gas prices into the gas price of a new transaction.
+type FeeStrategy interface {
+	GasPrice(stats GasPriceStats) int64
+}
+
+// MinimumStrategy pays the minimum gas price.
+type MinimumStrategy struct{}
+
+// GasPrice
+func (strategy MinimumStrategy) GasPrice(stats GasPriceStats) int64 {
+	return stats.MinGasPrice
+}
+
+// FastStrategy pays what the 90th percentile of recent transactions paid, and
+// at least the minimum.
+type FastStrategy struct{}
+
+// GasPrice
+func (strategy FastStrategy) GasPrice(stats GasPriceStats) int64 {
+	if stats.FastGasPrice > stats.MinGasPrice {
+		return stats.FastGasPrice
+	}
+	return stats.MinGasPrice
+}
+
+// MultiplierStrategy pays Multiplier times the price of Base, rounded up.
+type MultiplierStrategy struct {
+	Base       FeeStrategy
+	Multiplier float64
+}
+
+// GasPrice
+func (strategy MultiplierStrategy) GasPrice(stats GasPriceStats) int64 {
+	return int64(math.Ceil(float64(strategy.Base.GasPrice(stats)) * strategy.Multiplier))
+}
+
+// CappedStrategy pays the price of Base but never more than Cap.
+type CappedStrategy struct {
+	Base FeeStrategy
+	Cap  int64
+}
+
+// GasPrice
+func (strategy CappedStrategy) GasPrice(stats GasPriceStats) int64 {
+	price := strategy.Base.GasPrice(stats)
+	if price > strategy.Cap {
+		return strategy.Cap
+	}
+	return price
+}
+
+// GasPricer suggests the gas price of new transactions. BuildBlob asks the
+// GasPricer of the TransactionOperation when the request has no gas price.
+type GasPricer interface {
+	SuggestGasPrice() (int64, exception.SDKResponse)
+}
+
+type gasPriceSample struct {
+	ledgerSeq   int64
+	minGasPrice int64
+	paid        []int64
+}
+
+// GasPriceOracle follows the minimum gas price and the gas prices paid in the
+// last Window ledgers, 20 by default, and prices new transactions with
+// Strategy, MinimumStrategy by default.
+type GasPriceOracle struct {
+	Url      string
+	Network  model.Network
+	Window   int64
+	Strategy FeeStrategy
+
+	lock    sync.Mutex
+	samples []gasPriceSample
+}
+
+// Update reads the ledgers closed since the last update.
+func (oracle *GasPriceOracle) Update() exception.SDKResponse {
+	oracle.lock.Lock()
+	defer oracle.lock.Unlock()
+	window := oracle.Window
+	if window <= 0 {
+		window = defaultGasPriceWindow
+	}
+	block := BlockOperation{Url: oracle.Url, Network: oracle.Network}
+	resDataNumber := block.GetNumber()
+	if resDataNumber.ErrorCode != 0 {
+		return exception.SDKResponse{ErrorCode: resDataNumber.ErrorCode, ErrorDesc: resDataNumber.ErrorDesc}
+	}
+	latest := resDataNumber.Result.Header.BlockNumber
+	start := latest - window + 1
+	if len(oracle.samples) > 0 && oracle.samples[len(oracle.samples)-1].ledgerSeq >= start {
+		start = oracle.samples[len(oracle.samples)-1].ledgerSeq + 1
+	}
+	if start < 1 {
+		start = 1
+	}
+	for seq := start; seq <= latest; seq++ {
+		sample := gasPriceSample{ledgerSeq: seq}
+		var reqDataFees model.BlockGetFeesRequest
+		reqDataFees.SetBlockNumber(seq)
+		resDataFees := block.GetFees(reqDataFees)
+		if resDataFees.ErrorCode != 0 {
+			return exception.SDKResponse{ErrorCode: resDataFees.ErrorCode, ErrorDesc: resDataFees.ErrorDesc}
+		}
+		sample.minGasPrice = resDataFees.Result.Fees.GasPrice
+		var reqDataTxs model.BlockGetTransactionRequest
+		reqDataTxs.SetBlockNumber(seq)
+		resDataTxs := block.GetTransactions(reqDataTxs)
+		// the node reports a ledger without transactions as not found
+		if resDataTxs.ErrorCode != 0 && resDataTxs.ErrorCode != 4 {
+			return exception.SDKResponse{ErrorCode: resDataTxs.ErrorCode, ErrorDesc: resDataTxs.ErrorDesc}
+		}
+		for _, transaction := range resDataTxs.Result.Transactions {
+			sample.paid = append(sample.paid, transaction.Transaction.GasPrice)
+		}
+		oracle.samples = append(oracle.samples, sample)
+	}
+	if int64(len(oracle.samples)) > window {
+		oracle.samples = oracle.samples[int64(len(oracle.samples))-window:]
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+// Stats summarizes the ledgers read by the last update.
+func (oracle *GasPriceOracle) Stats() GasPriceStats {
+	oracle.lock.Lock()
+	defer oracle.lock.Unlock()
+	return gasPriceStats(oracle.samples)
+}
+
+func gasPriceStats(samples []gasPriceSample) GasPriceStats {
+	var stats GasPriceStats
+	if len(samples) == 0 {
+		return stats
+	}
+	latest := samples[len(samples)-1]
+	stats.LedgerSeq = latest.ledgerSeq
+	stats.MinGasPrice = latest.minGasPrice
+	var paid []int64
+	for _, sample := range samples {
+		paid = append(paid, sample.paid...)
+	}
+	stats.AverageTxCount = float64(len(paid)) / float64(len(samples))
+	if len(paid) == 0 {
+		stats.MedianGasPrice = stats.MinGasPrice
+		stats.FastGasPrice = stats.MinGasPrice
+		return stats
+	}
+	sort.Slice(paid, func(i, j int) bool { return paid[i] < paid[j] })
+	stats.MedianGasPrice = paid[(len(paid)-1)/2]
+	stats.FastGasPrice = paid[int(math.Ceil(float64(len(paid))*0.9))-1]
+	return stats
+}
+
+// SuggestGasPrice updates the oracle and applies the strategy. A price below the
+// minimum gas price, which a capped strategy can give, is an error because the
+// node would reject the transaction.
+func (oracle *GasPriceOracle) SuggestGasPrice() (int64, exception.SDKResponse) {
+	SDKRes := oracle.Update()
+	if SDKRes.ErrorCode != 0 {
+		return 0, SDKRes
+	}
+	stats := oracle.Stats()
+	strategy := oracle.Strategy
+	if strategy == nil {
+		strategy = MinimumStrategy{}
+	}
+	price := strategy.GasPrice(stats)
+	if price < stats.MinGasPrice {
+		return 0, exception.GetSDKRes(exception.GASPRICE_BELOW_MINIMUM_ERROR)
+	}
+	return price, exception.GetSDKRes(exception.SUCCESS)
+}
@@ -0,0 +1,83 @@
+// gasprice_test
+package blockchain_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/golang/protobuf/proto"
+)
+
+type fixedGasPricer int64
+
+func (pricer fixedGasPricer) SuggestGasPrice() (int64, exception.SDKResponse) {
+	return int64(pricer), exception.GetSDKRes(exception.SUCCESS)
+}
+
+func Test_FeeStrategy(t *testing.T) {
+	stats := blockchain.GasPriceStats{MinGasPrice: 1000, MedianGasPrice: 1000, FastGasPrice: 1500}
+	cases := []struct {
+		name     string
+		strategy blockchain.FeeStrategy
+		price    int64
+	}{
+		{"minimum", blockchain.MinimumStrategy{}, 1000},
+		{"fast", blockchain.FastStrategy{}, 1500},
+		{"multiplier", blockchain.MultiplierStrategy{Base: blockchain.MinimumStrategy{}, Multiplier: 1.25}, 1250},
+		{"capped", blockchain.CappedStrategy{Base: blockchain.FastStrategy{}, Cap: 1200}, 1200},
+		{"capped below", blockchain.CappedStrategy{Base: blockchain.MinimumStrategy{}, Cap: 1200}, 1000},
+	}
+	for _, c := range cases {
+		if price := c.strategy.GasPrice(stats); price != c.price {
+			t.Errorf("%s: got %d, want %d", c.name, price, c.price)
+		}
+	}
+	// an idle chain never pays less than the minimum
+	stats.FastGasPrice = 0
+	if price := (blockchain.FastStrategy{}).GasPrice(stats); price != 1000 {
+		t.Error("fast strategy pays", price)
+	}
+}
+
+// a request without gas price takes the one of the gas pricer
+func Test_BuildBlob_GasPricer(t *testing.T) {
+	transaction := blockchain.TransactionOperation{GasPricer: fixedGasPricer(1300)}
+	reqData := offlineBlobRequest()
+	reqData.SetGasPrice(0)
+	resData := transaction.BuildBlob(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	data, _ := hex.DecodeString(resData.Result.Blob)
+	var tx protocol.Transaction
+	proto.Unmarshal(data, &tx)
+	if tx.GasPrice != 1300 {
+		t.Error("gas price is", tx.GasPrice)
+	}
+}
+
+// the fee limit must cover the size of the transaction at the chosen gas price
+func Test_BuildBlob_GasPricerFeeLimit(t *testing.T) {
+	transaction := blockchain.TransactionOperation{GasPricer: fixedGasPricer(100000)}
+	reqData := offlineBlobRequest()
+	reqData.SetGasPrice(0)
+	if resData := transaction.BuildBlob(reqData); resData.ErrorCode != exception.INVALID_FEELIMIT_ERROR {
+		t.Error("fee limit below the fee is accepted:", resData.ErrorDesc)
+	}
+	// a fee limit left to the SDK is raised to the fee
+	reqData.SetFeeLimit(0)
+	resData := transaction.BuildBlob(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	data, _ := hex.DecodeString(resData.Result.Blob)
+	var tx protocol.Transaction
+	proto.Unmarshal(data, &tx)
+	env := protocol.TransactionEnv{Transaction: &tx, Signatures: []*protocol.Signature{{PublicKey: string(make([]byte, 76)), SignData: make([]byte, 64)}}}
+	if tx.GasPrice != 100000 || tx.FeeLimit != int64(proto.Size(&env))*100000 {
+		t.Errorf("wrong fees %d %d", tx.GasPrice, tx.FeeLimit)
+	}
+}
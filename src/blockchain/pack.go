@@ -0,0 +1,70 @@
+// pack
+package blockchain
+
+import (
+	"math"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// MAX_TRANSACTION_OPERATIONS is the most operations a transaction may hold.
+	MAX_TRANSACTION_OPERATIONS int = 1000
+	// DEFAULT_MAX_TRANSACTION_SIZE is the serialized size, in bytes, that
+	// transactions packed by the SDK are kept under by default.
+	DEFAULT_MAX_TRANSACTION_SIZE int = 256 * 1024
+)
+
+// the bytes a length-delimited operation adds to the transaction
+const operationOverhead = 4
+
+// OperationSize is the number of bytes the operation adds to a transaction.
+func OperationSize(operation *protocol.Operation) int {
+	return proto.Size(operation) + operationOverhead
+}
+
+// EnvelopeSize is the largest serialized size of a transaction of the source
+// and its signatures without operations.
+func EnvelopeSize(sourceAddress string, metadata string, signatureNumber int) int {
+	env := protocol.TransactionEnv{Transaction: &protocol.Transaction{
+		SourceAddress: sourceAddress,
+		Nonce:         math.MaxInt64,
+		FeeLimit:      math.MaxInt64,
+		GasPrice:      math.MaxInt64,
+		CeilLedgerSeq: math.MaxInt64,
+		Metadata:      []byte(metadata),
+	}}
+	for i := 0; i < signatureNumber; i++ {
+		env.Signatures = append(env.Signatures, &protocol.Signature{PublicKey: placeholderPublicKey, SignData: placeholderSignData})
+	}
+	return proto.Size(&env)
+}
+
+// PackOperations splits operations of the given sizes, in order, into
+// transactions of at most maxOperations operations and maxSize bytes, the
+// envelope included, and returns the number of operations of each. Limits of
+// 0 are MAX_TRANSACTION_OPERATIONS and DEFAULT_MAX_TRANSACTION_SIZE. An
+// operation larger than the limit gets a transaction of its own.
+func PackOperations(sizes []int, envelope int, maxOperations int, maxSize int) []int {
+	if maxOperations <= 0 || maxOperations > MAX_TRANSACTION_OPERATIONS {
+		maxOperations = MAX_TRANSACTION_OPERATIONS
+	}
+	if maxSize <= 0 {
+		maxSize = DEFAULT_MAX_TRANSACTION_SIZE
+	}
+	var counts []int
+	count, size := 0, envelope
+	for _, operationSize := range sizes {
+		if count != 0 && (count == maxOperations || size+operationSize > maxSize) {
+			counts = append(counts, count)
+			count, size = 0, envelope
+		}
+		count++
+		size += operationSize
+	}
+	if count != 0 {
+		counts = append(counts, count)
+	}
+	return counts
+}
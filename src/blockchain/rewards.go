@@ -0,0 +1,196 @@
+// rewards
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// GetRewardSample reads the reward distribution with the number of the
+// latest ledger, read first. The reward of each validator and KOL is the
+// first entry of its distribution, in MO, and they are ordered by address as
+// GetLatestReward orders them.
+func (block *BlockOperation) GetRewardSample() model.BlockGetRewardSampleResponse {
+	var resData model.BlockGetRewardSampleResponse
+	resDataNumber := block.GetNumber()
+	if resDataNumber.ErrorCode != 0 {
+		resData.ErrorCode = resDataNumber.ErrorCode
+		resData.ErrorDesc = resDataNumber.ErrorDesc
+		return resData
+	}
+	resDataReward := block.GetLatestReward()
+	if resDataReward.ErrorCode != 0 {
+		resData.ErrorCode = resDataReward.ErrorCode
+		resData.ErrorDesc = resDataReward.ErrorDesc
+		return resData
+	}
+	validators, err := addressRewards(resDataReward.Result.Validators)
+	if err == nil {
+		resData.Result.Kols, err = addressRewards(resDataReward.Result.Kols)
+	}
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc + ": " + err.Error()
+		return resData
+	}
+	resData.Result.LedgerSeq = resDataNumber.Result.Header.BlockNumber
+	resData.Result.Validators = validators
+	return resData
+}
+
+// sortRewards orders a reward distribution by address.
+func sortRewards(rewards []model.Rewards) {
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Address < rewards[j].Address })
+}
+
+func addressRewards(rewards []model.Rewards) ([]model.AddressReward, error) {
+	amounts := make([]model.AddressReward, len(rewards))
+	for i, reward := range rewards {
+		amounts[i].Address = reward.Address
+		if len(reward.Reward) != 0 {
+			amount, err := rewardAmount(reward.Reward[0])
+			if err != nil {
+				return nil, fmt.Errorf("reward of %s: %v", reward.Address, err)
+			}
+			amounts[i].Reward = amount
+		}
+	}
+	return amounts, nil
+}
+
+// the contract gives amounts as strings of MO
+func rewardAmount(value interface{}) (int64, error) {
+	switch value := value.(type) {
+	case string:
+		return strconv.ParseInt(value, 10, 64)
+	case json.Number:
+		return value.Int64()
+	case float64:
+		if value != float64(int64(value)) {
+			return 0, fmt.Errorf("%v is not a whole amount", value)
+		}
+		return int64(value), nil
+	}
+	return 0, fmt.Errorf("%v is not an amount", value)
+}
+
+// RewardHistory keeps samples of the reward distribution, taken with
+// GetRewardSample, and reports the rewards earned over ledger ranges. The
+// contract only gives the current distribution, so the history holds what
+// was sampled.
+//
+// The distribution holds the rewards accumulated since they were last
+// withdrawn: the reward earned between two samples is the growth of the
+// accumulated reward, or the whole later reward when it shrank.
+type RewardHistory struct {
+	lock    sync.Mutex
+	samples []model.RewardSample
+}
+
+// NewRewardHistory
+func NewRewardHistory() *RewardHistory {
+	return &RewardHistory{}
+}
+
+// Add records a sample, replacing an earlier sample of the same ledger.
+func (history *RewardHistory) Add(sample model.RewardSample) {
+	history.lock.Lock()
+	defer history.lock.Unlock()
+	i := sort.Search(len(history.samples), func(i int) bool { return history.samples[i].LedgerSeq >= sample.LedgerSeq })
+	if i < len(history.samples) && history.samples[i].LedgerSeq == sample.LedgerSeq {
+		history.samples[i] = sample
+		return
+	}
+	history.samples = append(history.samples, model.RewardSample{})
+	copy(history.samples[i+1:], history.samples[i:])
+	history.samples[i] = sample
+}
+
+// Samples returns the samples of the ledgers from start to end, both
+// included, in ledger order. An end of 0 is the last sample.
+func (history *RewardHistory) Samples(start int64, end int64) []model.RewardSample {
+	history.lock.Lock()
+	defer history.lock.Unlock()
+	var samples []model.RewardSample
+	for _, sample := range history.samples {
+		if sample.LedgerSeq >= start && (end == 0 || sample.LedgerSeq <= end) {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// Report sums the rewards earned between the samples from start to end. The
+// first sample of the range is the base the rewards are counted from, and
+// averages are per ledger between the first and last samples. Validators and
+// KOLs are ordered by reward, the largest first, then by address.
+func (history *RewardHistory) Report(start int64, end int64) model.RewardReport {
+	samples := history.Samples(start, end)
+	var report model.RewardReport
+	report.Samples = len(samples)
+	if len(samples) == 0 {
+		return report
+	}
+	report.StartSeq = samples[0].LedgerSeq
+	report.EndSeq = samples[len(samples)-1].LedgerSeq
+	ledgers := report.EndSeq - report.StartSeq
+	validators := make([][]model.AddressReward, len(samples))
+	kols := make([][]model.AddressReward, len(samples))
+	for i, sample := range samples {
+		validators[i] = sample.Validators
+		kols[i] = sample.Kols
+	}
+	report.Validators, report.ValidatorTotal = rewardTotals(validators, ledgers)
+	report.Kols, report.KolTotal = rewardTotals(kols, ledgers)
+	if ledgers > 0 {
+		report.ValidatorAverage = float64(report.ValidatorTotal) / float64(ledgers)
+		report.KolAverage = float64(report.KolTotal) / float64(ledgers)
+	}
+	return report
+}
+
+// rewardTotals sums the rewards earned between consecutive distributions. An
+// address missing from some distributions is counted from the last one it
+// appears in.
+func rewardTotals(distributions [][]model.AddressReward, ledgers int64) ([]model.RewardTotal, int64) {
+	earned := make(map[string]int64)
+	last := make(map[string]int64)
+	for i, distribution := range distributions {
+		for _, reward := range distribution {
+			gain := reward.Reward - last[reward.Address]
+			if i == 0 {
+				// the base the rewards are counted from
+				gain = 0
+			} else if gain < 0 {
+				// withdrawn since it was last seen
+				gain = reward.Reward
+			}
+			last[reward.Address] = reward.Reward
+			earned[reward.Address] += gain
+		}
+	}
+	var total int64
+	totals := make([]model.RewardTotal, 0, len(earned))
+	for address, reward := range earned {
+		rewardTotal := model.RewardTotal{Address: address, Reward: reward}
+		if ledgers > 0 {
+			rewardTotal.AveragePerLedger = float64(reward) / float64(ledgers)
+		}
+		totals = append(totals, rewardTotal)
+		total += reward
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Reward != totals[j].Reward {
+			return totals[i].Reward > totals[j].Reward
+		}
+		return totals[i].Address < totals[j].Address
+	})
+	return totals, total
+}
@@ -0,0 +1,110 @@
+// scanner
+package blockchain
+
+import (
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// LedgerHandler receives one closed ledger with its transactions. Returning an
+// error stops the scan before the ledger is counted as scanned.
+type LedgerHandler func(header model.GetInfoHeader, transactions []model.Transactioninfo) error
+
+// LedgerScanner walks closed ledgers in ascending order. Transactions triggered
+// by contracts are fetched by hash and follow the transaction that triggered
+// them, so a handler sees every balance change of the ledger.
+type LedgerScanner struct {
+	Url     string
+	Network model.Network
+}
+
+// Scan hands the ledgers from start to end, both included, to the handler. An
+// end of 0 scans up to the latest ledger. The returned sequence is the next
+// ledger to scan, so a stopped scan can be resumed from it.
+func (scanner *LedgerScanner) Scan(start int64, end int64, handler LedgerHandler) (int64, exception.SDKResponse) {
+	if start <= 0 || end < 0 || (end != 0 && end < start) {
+		return start, exception.GetSDKRes(exception.INVALID_BLOCKNUMBER_ERROR)
+	}
+	block := BlockOperation{Url: scanner.Url, Network: scanner.Network}
+	if end == 0 {
+		resDataNumber := block.GetNumber()
+		if resDataNumber.ErrorCode != 0 {
+			return start, exception.SDKResponse{ErrorCode: resDataNumber.ErrorCode, ErrorDesc: resDataNumber.ErrorDesc}
+		}
+		end = resDataNumber.Result.Header.BlockNumber
+	}
+	for seq := start; seq <= end; seq++ {
+		header, transactions, SDKRes := scanner.GetLedger(seq)
+		if SDKRes.ErrorCode != 0 {
+			return seq, SDKRes
+		}
+		err := handler(header, transactions)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
+			SDKRes.ErrorDesc = err.Error()
+			return seq, SDKRes
+		}
+	}
+	return end + 1, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// GetLedger returns the header and the transactions of one ledger, including
+// the transactions triggered by contracts.
+func (scanner *LedgerScanner) GetLedger(seq int64) (model.GetInfoHeader, []model.Transactioninfo, exception.SDKResponse) {
+	block := BlockOperation{Url: scanner.Url, Network: scanner.Network}
+	var reqDataInfo model.BlockGetInfoRequest
+	reqDataInfo.SetBlockNumber(seq)
+	resDataInfo := block.GetInfo(reqDataInfo)
+	if resDataInfo.ErrorCode != 0 {
+		return model.GetInfoHeader{}, nil, exception.SDKResponse{ErrorCode: resDataInfo.ErrorCode, ErrorDesc: resDataInfo.ErrorDesc}
+	}
+	header := resDataInfo.Result.Header
+	var reqDataTxs model.BlockGetTransactionRequest
+	reqDataTxs.SetBlockNumber(seq)
+	resDataTxs := block.GetTransactions(reqDataTxs)
+	// the node reports a ledger without transactions as not found
+	if resDataTxs.ErrorCode == 4 {
+		return header, nil, exception.GetSDKRes(exception.SUCCESS)
+	}
+	if resDataTxs.ErrorCode != 0 {
+		return header, nil, exception.SDKResponse{ErrorCode: resDataTxs.ErrorCode, ErrorDesc: resDataTxs.ErrorDesc}
+	}
+	seen := make(map[string]bool)
+	for _, transaction := range resDataTxs.Result.Transactions {
+		seen[transaction.Hash] = true
+	}
+	var transactions []model.Transactioninfo
+	for _, transaction := range resDataTxs.Result.Transactions {
+		var SDKRes exception.SDKResponse
+		transactions, SDKRes = scanner.appendTriggered(transactions, transaction, seen)
+		if SDKRes.ErrorCode != 0 {
+			return header, nil, SDKRes
+		}
+	}
+	return header, transactions, exception.GetSDKRes(exception.SUCCESS)
+}
+
+func (scanner *LedgerScanner) appendTriggered(transactions []model.Transactioninfo, transaction model.Transactioninfo, seen map[string]bool) ([]model.Transactioninfo, exception.SDKResponse) {
+	transactions = append(transactions, transaction)
+	tx := TransactionOperation{Url: scanner.Url, Network: scanner.Network}
+	for _, hash := range transaction.ContractTxHashes {
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		var reqData model.TransactionGetInfoRequest
+		reqData.SetHash(hash)
+		resData := tx.GetInfo(reqData)
+		if resData.ErrorCode != 0 {
+			return nil, exception.SDKResponse{ErrorCode: resData.ErrorCode, ErrorDesc: resData.ErrorDesc}
+		}
+		for _, triggered := range resData.Result.Transactions {
+			var SDKRes exception.SDKResponse
+			transactions, SDKRes = scanner.appendTriggered(transactions, triggered, seen)
+			if SDKRes.ErrorCode != 0 {
+				return nil, SDKRes
+			}
+		}
+	}
+	return transactions, exception.GetSDKRes(exception.SUCCESS)
+}
@@ -17,13 +17,15 @@ import (
 )
 
 type TransactionOperation struct {
-	Url string
+	Url       string
+	Network   model.Network
+	GasPricer GasPricer
 }
 
 // build blob
 func (transaction *TransactionOperation) BuildBlob(reqData model.TransactionBuildBlobRequest) model.TransactionBuildBlobResponse {
 	var resData model.TransactionBuildBlobResponse
-	if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+	if !transaction.Network.CheckAddress(reqData.GetSourceAddress()) {
 		SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -60,7 +62,26 @@ func (transaction *TransactionOperation) BuildBlob(reqData model.TransactionBuil
 		resData.ErrorDesc = SDKRes.ErrorDesc
 		return resData
 	}
-	operations, SDKRes := common.GetOperations(operationsData, transaction.Url, reqData.GetSourceAddress())
+	// without a gas price in the request, the gas pricer chooses it, or else
+	// the network gives it, as it gives the fee limit
+	network := transaction.Network.Resolve()
+	choosePrice, chooseFeeLimit := reqData.GetGasPrice() == 0, reqData.GetFeeLimit() == 0
+	if choosePrice && transaction.GasPricer != nil {
+		gasPrice, SDKRes := transaction.GasPricer.SuggestGasPrice()
+		if SDKRes.ErrorCode != 0 {
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+		reqData.SetGasPrice(gasPrice)
+	}
+	if reqData.GetGasPrice() == 0 {
+		reqData.SetGasPrice(network.GasPrice)
+	}
+	if chooseFeeLimit {
+		reqData.SetFeeLimit(network.FeeLimit)
+	}
+	operations, SDKRes := common.GetOperations(operationsData, transaction.Url, reqData.GetSourceAddress(), transaction.Network)
 	if SDKRes.ErrorCode != 0 {
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -99,12 +120,30 @@ func (transaction *TransactionOperation) BuildBlob(reqData model.TransactionBuil
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	var seq int64 = 0
-	if reqData.GetCeilLedgerSeq() > 0 {
-		var Block BlockOperation
-		Block.Url = transaction.Url
-		resDataNumber := Block.GetNumber()
-		seq = reqData.GetCeilLedgerSeq() + resDataNumber.Result.Header.BlockNumber
+	// with chain params the blob is built offline, without the node
+	params := reqData.GetChainParams()
+	if params != nil {
+		if reqData.GetGasPrice() < params.GasPrice {
+			SDKRes := exception.GetSDKRes(exception.INVALID_GASPRICE_ERROR)
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+		for i := range operations {
+			if operations[i].Type == protocol.Operation_CREATE_ACCOUNT &&
+				operations[i].GetCreateAccount().GetInitBalance() < params.BaseReserve {
+				SDKRes := exception.GetSDKRes(exception.INVALID_INITBALANCE_ERROR)
+				resData.ErrorCode = SDKRes.ErrorCode
+				resData.ErrorDesc = SDKRes.ErrorDesc
+				return resData
+			}
+		}
+	}
+	seq, SDKRes := transaction.ceilLedgerSeq(reqData)
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
 	}
 	Transaction := protocol.Transaction{
 		SourceAddress: reqData.GetSourceAddress(),
@@ -115,6 +154,21 @@ func (transaction *TransactionOperation) BuildBlob(reqData model.TransactionBuil
 		Metadata:      []byte(reqData.GetMetadata()),
 		Operations:    operations,
 	}
+	// a chosen gas price may need more than the fee limit: a chosen fee limit
+	// is raised to cover the size of the transaction, a given one is refused
+	if choosePrice || chooseFeeLimit {
+		fee := signedSizeFee(&Transaction)
+		if Transaction.FeeLimit < fee && chooseFeeLimit {
+			fee = TransactionFee(&Transaction, 1, Transaction.GasPrice)
+		}
+		if Transaction.FeeLimit < fee {
+			SDKRes := exception.GetSDKRes(exception.INVALID_FEELIMIT_ERROR)
+			SDKRes.ErrorDesc += ": " + strconv.FormatInt(Transaction.FeeLimit, 10) + " is below the fee " + strconv.FormatInt(fee, 10) + " at gas price " + strconv.FormatInt(Transaction.GasPrice, 10)
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+	}
 	data, err := proto.Marshal(&Transaction)
 	if err != nil {
 		SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
@@ -128,11 +182,53 @@ func (transaction *TransactionOperation) BuildBlob(reqData model.TransactionBuil
 	return resData
 }
 
+// ceilLedgerSeq resolves the expiry of the request into the last ledger the
+// transaction can be included in, 0 for no limit. The current ledger comes from
+// the chain params when they are set, and from the node otherwise.
+func (transaction *TransactionOperation) ceilLedgerSeq(reqData model.TransactionBuildBlobRequest) (int64, exception.SDKResponse) {
+	params := reqData.GetChainParams()
+	block := BlockOperation{Url: transaction.Url, Network: transaction.Network}
+	switch reqData.GetExpiryMode() {
+	case model.EXPIRY_ABSOLUTE:
+		return reqData.GetCeilLedgerSeq(), exception.GetSDKRes(exception.SUCCESS)
+	case model.EXPIRY_TIME:
+		if params == nil {
+			resDataParams := block.GetChainParams()
+			if resDataParams.ErrorCode != 0 {
+				return 0, exception.SDKResponse{ErrorCode: resDataParams.ErrorCode, ErrorDesc: resDataParams.ErrorDesc}
+			}
+			params = &resDataParams.Result
+		}
+		if params.CloseInterval <= 0 {
+			return 0, exception.GetSDKRes(exception.INVALID_EXPIRYTIME_ERROR)
+		}
+		// close times are in microseconds
+		remaining := reqData.GetExpiryTime().UnixNano()/1000 - params.CloseTime
+		ledgers := remaining / params.CloseInterval
+		if ledgers < 1 {
+			return 0, exception.GetSDKRes(exception.INVALID_EXPIRYTIME_ERROR)
+		}
+		return params.LedgerSeq + ledgers, exception.GetSDKRes(exception.SUCCESS)
+	default:
+		if reqData.GetCeilLedgerSeq() == 0 {
+			return 0, exception.GetSDKRes(exception.SUCCESS)
+		}
+		if params != nil {
+			return params.LedgerSeq + reqData.GetCeilLedgerSeq(), exception.GetSDKRes(exception.SUCCESS)
+		}
+		resDataNumber := block.GetNumber()
+		if resDataNumber.ErrorCode != 0 {
+			return 0, exception.SDKResponse{ErrorCode: resDataNumber.ErrorCode, ErrorDesc: resDataNumber.ErrorDesc}
+		}
+		return resDataNumber.Result.Header.BlockNumber + reqData.GetCeilLedgerSeq(), exception.GetSDKRes(exception.SUCCESS)
+	}
+}
+
 // evaluate fee
 func (transaction *TransactionOperation) EvaluateFee(reqData model.TransactionEvaluateFeeRequest) model.TransactionEvaluateFeeResponse {
 	var resDataD model.TransactionEvaluateFeeData
 	var resData model.TransactionEvaluateFeeResponse
-	if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+	if !transaction.Network.CheckAddress(reqData.GetSourceAddress()) {
 		SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -167,7 +263,7 @@ func (transaction *TransactionOperation) EvaluateFee(reqData model.TransactionEv
 			return resData
 		}
 	}
-	operations, SDKRes := common.GetOperations(operationsData, transaction.Url, reqData.GetSourceAddress())
+	operations, SDKRes := common.GetOperations(operationsData, transaction.Url, reqData.GetSourceAddress(), transaction.Network)
 	if SDKRes.ErrorCode != 0 {
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -192,7 +288,13 @@ func (transaction *TransactionOperation) EvaluateFee(reqData model.TransactionEv
 	if reqData.GetCeilLedgerSeq() > 0 {
 		var Block BlockOperation
 		Block.Url = transaction.Url
+		Block.Network = transaction.Network
 		resDataNumber := Block.GetNumber()
+		if resDataNumber.ErrorCode != 0 {
+			resData.ErrorCode = resDataNumber.ErrorCode
+			resData.ErrorDesc = resDataNumber.ErrorDesc
+			return resData
+		}
 		seq = reqData.GetCeilLedgerSeq() + resDataNumber.Result.Header.BlockNumber
 	}
 	request := &model.WebTransactionEvaluateFeeResponse{
@@ -0,0 +1,147 @@
+// transaction_test
+package blockchain_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	sourceAddress = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+	destAddress   = "buQBjJD1BSJ7nzAbzdTenAhpFjmxRVEEtmxH"
+)
+
+func offlineBlobRequest() model.TransactionBuildBlobRequest {
+	var send model.BUSendOperation
+	send.Init()
+	send.SetSourceAddress(sourceAddress)
+	send.SetDestAddress(destAddress)
+	send.SetAmount(100000)
+	var reqData model.TransactionBuildBlobRequest
+	reqData.SetSourceAddress(sourceAddress)
+	reqData.SetNonce(8)
+	reqData.SetGasPrice(1000)
+	reqData.SetFeeLimit(1000000)
+	reqData.SetCeilLedgerSeq(50)
+	reqData.SetOperation(send)
+	reqData.SetChainParams(model.ChainParams{GasPrice: 1000, BaseReserve: 10000000, LedgerSeq: 581283})
+	return reqData
+}
+
+// with chain params the blob is built without a node url
+func Test_BuildBlob_Offline(t *testing.T) {
+	var transaction blockchain.TransactionOperation
+	resData := transaction.BuildBlob(offlineBlobRequest())
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	data, _ := hex.DecodeString(resData.Result.Blob)
+	var tx protocol.Transaction
+	err := proto.Unmarshal(data, &tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.CeilLedgerSeq != 581333 || tx.Nonce != 8 || tx.Operations[0].GetPayCoin().GetAmount() != 100000 {
+		t.Errorf("wrong transaction %v", tx)
+	}
+}
+
+// a request without fees takes those of the network
+func Test_BuildBlob_NetworkFees(t *testing.T) {
+	transaction := blockchain.TransactionOperation{Network: model.Network{GasPrice: 1200}}
+	reqData := offlineBlobRequest()
+	reqData.SetGasPrice(0)
+	reqData.SetFeeLimit(0)
+	resData := transaction.BuildBlob(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	data, _ := hex.DecodeString(resData.Result.Blob)
+	var tx protocol.Transaction
+	proto.Unmarshal(data, &tx)
+	if tx.GasPrice != 1200 || tx.FeeLimit != model.MainnetNetwork().FeeLimit {
+		t.Errorf("wrong fees %d %d", tx.GasPrice, tx.FeeLimit)
+	}
+}
+
+func Test_BuildBlob_OfflineChecks(t *testing.T) {
+	var transaction blockchain.TransactionOperation
+	reqData := offlineBlobRequest()
+	reqData.SetGasPrice(999)
+	resData := transaction.BuildBlob(reqData)
+	if resData.ErrorCode == 0 {
+		t.Error("gas price below the chain gas price is accepted")
+	}
+	var activate model.AccountActivateOperation
+	activate.Init()
+	activate.SetDestAddress(destAddress)
+	activate.SetInitBalance(9999999)
+	reqData = offlineBlobRequest()
+	reqData.SetOperation(activate)
+	resData = transaction.BuildBlob(reqData)
+	if resData.ErrorCode == 0 {
+		t.Error("init balance below the base reserve is accepted")
+	}
+}
+
+func blobCeilLedgerSeq(t *testing.T, reqData model.TransactionBuildBlobRequest) int64 {
+	var transaction blockchain.TransactionOperation
+	resData := transaction.BuildBlob(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	data, _ := hex.DecodeString(resData.Result.Blob)
+	var tx protocol.Transaction
+	proto.Unmarshal(data, &tx)
+	return tx.CeilLedgerSeq
+}
+
+// an absolute expiry is kept, a wall-clock expiry is turned into ledgers
+func Test_BuildBlob_Expiry(t *testing.T) {
+	reqData := offlineBlobRequest()
+	reqData.SetAbsoluteCeilLedgerSeq(600000)
+	if seq := blobCeilLedgerSeq(t, reqData); seq != 600000 {
+		t.Error("absolute expiry is", seq)
+	}
+	closeTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reqData.SetChainParams(model.ChainParams{GasPrice: 1000, LedgerSeq: 581283, CloseTime: closeTime.UnixNano() / 1000, CloseInterval: 10000000})
+	reqData.SetExpiryTime(closeTime.Add(10 * time.Minute))
+	if seq := blobCeilLedgerSeq(t, reqData); seq != 581343 {
+		t.Error("wall-clock expiry is", seq)
+	}
+	var transaction blockchain.TransactionOperation
+	reqData.SetExpiryTime(closeTime.Add(5 * time.Second))
+	resData := transaction.BuildBlob(reqData)
+	if resData.ErrorCode != 11072 {
+		t.Error("expiry inside the current ledger is accepted")
+	}
+}
+
+// without chain params and without a node the height error is reported
+func Test_BuildBlob_HeightError(t *testing.T) {
+	transaction := blockchain.TransactionOperation{Url: "http://127.0.0.1:1"}
+	reqData := offlineBlobRequest()
+	reqData.SetChainParams(model.ChainParams{})
+	reqData.SetCeilLedgerSeq(10)
+	if seq := blobCeilLedgerSeq(t, reqData); seq != 10 {
+		t.Error("relative expiry is", seq)
+	}
+	var withoutParams model.TransactionBuildBlobRequest
+	withoutParams.SetSourceAddress(sourceAddress)
+	withoutParams.SetNonce(8)
+	withoutParams.SetGasPrice(1000)
+	withoutParams.SetFeeLimit(1000000)
+	withoutParams.SetCeilLedgerSeq(10)
+	operations := reqData.GetOperations()
+	withoutParams.SetOperation(operations.Front().Value.(model.BaseOperation))
+	resData := transaction.BuildBlob(withoutParams)
+	if resData.ErrorCode == 0 {
+		t.Error("blob is built without the current height")
+	}
+}
@@ -0,0 +1,174 @@
+// validators
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/bumoproject/bumo-sdk-go/src/common"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// GetValidatorPledges returns the validators of a ledger, the latest when the
+// block number is 0, with their pledge and their share of the total pledge.
+// The largest pledge comes first, then the validators are ordered by address.
+func (block *BlockOperation) GetValidatorPledges(reqData model.BlockGetValidatorsRequest) model.BlockGetValidatorPledgesResponse {
+	var resData model.BlockGetValidatorPledgesResponse
+	if reqData.GetBlockNumber() < 0 {
+		SDKRes := exception.GetSDKRes(exception.INVALID_BLOCKNUMBER_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	ledgerSeq, validators, SDKRes := block.validators(reqData.GetBlockNumber())
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	resData.Result.LedgerSeq = ledgerSeq
+	for _, validator := range validators {
+		resData.Result.TotalPledge += validator.PledgeCoinAmount
+	}
+	resData.Result.Validators = make([]model.ValidatorPledge, len(validators))
+	for i, validator := range validators {
+		pledge := &resData.Result.Validators[i]
+		pledge.Address = validator.Address
+		pledge.PledgeCoinAmount = validator.PledgeCoinAmount
+		if resData.Result.TotalPledge > 0 {
+			pledge.Share = float64(validator.PledgeCoinAmount) / float64(resData.Result.TotalPledge)
+		}
+	}
+	sort.Slice(resData.Result.Validators, func(i, j int) bool {
+		a, b := resData.Result.Validators[i], resData.Result.Validators[j]
+		if a.PledgeCoinAmount != b.PledgeCoinAmount {
+			return a.PledgeCoinAmount > b.PledgeCoinAmount
+		}
+		return a.Address < b.Address
+	})
+	return resData
+}
+
+// validators reads the validators of a ledger, the latest for 0, ordered by
+// address. Nodes that list the addresses only give a pledge of 0.
+func (block *BlockOperation) validators(ledgerSeq int64) (int64, []model.Validator, exception.SDKResponse) {
+	str := "with_validator=true"
+	if ledgerSeq > 0 {
+		str = "seq=" + strconv.FormatInt(ledgerSeq, 10) + "&" + str
+	}
+	response, SDKRes := common.GetRequest(block.Url, "/getLedger?", str)
+	if SDKRes.ErrorCode != 0 {
+		return 0, nil, SDKRes
+	}
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		return 0, nil, exception.GetSDKRes(exception.CONNECTNETWORK_ERROR)
+	}
+	var resData struct {
+		ErrorCode int    `json:"error_code"`
+		ErrorDesc string `json:"error_desc"`
+		Result    struct {
+			Header     model.GetInfoHeader `json:"header"`
+			Validators []json.RawMessage   `json:"validators"`
+		} `json:"result"`
+	}
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+	err := decoder.Decode(&resData)
+	if err != nil {
+		return 0, nil, exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	if resData.ErrorCode != 0 {
+		if resData.ErrorCode == 4 {
+			resData.ErrorDesc = "Get block failed"
+		}
+		return 0, nil, exception.SDKResponse{ErrorCode: resData.ErrorCode, ErrorDesc: resData.ErrorDesc}
+	}
+	validators := make([]model.Validator, len(resData.Result.Validators))
+	for i, data := range resData.Result.Validators {
+		err := json.Unmarshal(data, &validators[i].Address)
+		if err != nil {
+			err = json.Unmarshal(data, &validators[i])
+		}
+		if err != nil {
+			return 0, nil, exception.GetSDKRes(exception.SYSTEM_ERROR)
+		}
+	}
+	sort.Slice(validators, func(i, j int) bool { return validators[i].Address < validators[j].Address })
+	return resData.Result.Header.Number, validators, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// CompareValidators lists the validators added to, removed from and with a
+// changed pledge in a validator set, each ordered by address. Added and
+// changed validators carry their new pledge, removed ones their last.
+func CompareValidators(ledgerSeq int64, before []model.Validator, after []model.Validator) model.ValidatorChange {
+	change := model.ValidatorChange{LedgerSeq: ledgerSeq}
+	pledges := make(map[string]int64, len(before))
+	for _, validator := range before {
+		pledges[validator.Address] = validator.PledgeCoinAmount
+	}
+	current := make(map[string]bool, len(after))
+	for _, validator := range after {
+		current[validator.Address] = true
+		pledge, ok := pledges[validator.Address]
+		if !ok {
+			change.Added = append(change.Added, validator)
+		} else if pledge != validator.PledgeCoinAmount {
+			change.Changed = append(change.Changed, validator)
+		}
+	}
+	for _, validator := range before {
+		if !current[validator.Address] {
+			change.Removed = append(change.Removed, validator)
+		}
+	}
+	for _, validators := range [][]model.Validator{change.Added, change.Removed, change.Changed} {
+		sort.Slice(validators, func(i, j int) bool { return validators[i].Address < validators[j].Address })
+	}
+	return change
+}
+
+// ValidatorTracker records the changes of the validator set over scanned
+// ledgers. Its Apply method is a LedgerHandler. The validators are only read
+// when the validators hash of the ledger header changes, and the first scanned
+// ledger gives the set the changes are counted from.
+type ValidatorTracker struct {
+	Url     string
+	Network model.Network
+	Changes []model.ValidatorChange
+
+	hash       string
+	validators []model.Validator
+	seeded     bool
+}
+
+// Apply
+func (tracker *ValidatorTracker) Apply(header model.GetInfoHeader, transactions []model.Transactioninfo) error {
+	if tracker.seeded && header.ValidatorsHash == tracker.hash {
+		return nil
+	}
+	block := BlockOperation{Url: tracker.Url, Network: tracker.Network}
+	_, validators, SDKRes := block.validators(header.Number)
+	if SDKRes.ErrorCode != 0 {
+		return errors.New(SDKRes.ErrorDesc)
+	}
+	if tracker.seeded {
+		change := CompareValidators(header.Number, tracker.validators, validators)
+		if len(change.Added) != 0 || len(change.Removed) != 0 || len(change.Changed) != 0 {
+			tracker.Changes = append(tracker.Changes, change)
+		}
+	}
+	tracker.hash = header.ValidatorsHash
+	tracker.validators = validators
+	tracker.seeded = true
+	return nil
+}
+
+// Validators returns the validator set of the last scanned ledger, ordered by
+// address.
+func (tracker *ValidatorTracker) Validators() []model.Validator {
+	return tracker.validators
+}
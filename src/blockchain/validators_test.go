@@ -0,0 +1,154 @@
+// validators_test
+package blockchain_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const (
+	alice = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+	bob   = "buQBjJD1BSJ7nzAbzdTenAhpFjmxRVEEtmxH"
+	carol = "buQtfFxpQP9JCFgmu4WBojBbEnVyQGaJDgGn"
+)
+
+// a node whose validator set changes at ledger 3, where carol replaces bob
+// and the pledge of alice grows, and whose latest ledger is 3
+func validatorNode() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getLedger":
+			seq := r.URL.Query().Get("seq")
+			if seq == "1" || seq == "2" {
+				w.Write([]byte(`{"error_code":0,"result":{"header":{"seq":` + seq + `},"validators":[
+					{"address":"` + bob + `","pledge_coin_amount":300},{"address":"` + alice + `","pledge_coin_amount":100}]}}`))
+				return
+			}
+			w.Write([]byte(`{"error_code":0,"result":{"header":{"seq":3},"validators":[
+				{"address":"` + carol + `","pledge_coin_amount":200},{"address":"` + alice + `","pledge_coin_amount":200}]}}`))
+		case "/callContract":
+			w.Write([]byte(`{"error_code":0,"result":{"query_rets":[{"result":{"value":"{\"rewards\":{\"validators\":{\"` + carol + `\":[\"70\",\"0\"],\"` + alice + `\":[\"50\",\"0\"]},\"kols\":{\"` + bob + `\":[\"5\",\"0\"]}}}"}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_GetValidatorPledges(t *testing.T) {
+	server := validatorNode()
+	defer server.Close()
+	block := blockchain.BlockOperation{Url: server.URL}
+
+	var reqData model.BlockGetValidatorsRequest
+	reqData.SetBlockNumber(1)
+	resData := block.GetValidatorPledges(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	validators := resData.Result.Validators
+	if resData.Result.LedgerSeq != 1 || resData.Result.TotalPledge != 400 || len(validators) != 2 {
+		t.Fatalf("wrong result %+v", resData.Result)
+	}
+	if validators[0].Address != bob || validators[0].Share != 0.75 || validators[1].Address != alice {
+		t.Errorf("wrong order %+v", validators)
+	}
+	// equal pledges are ordered by address
+	resData = block.GetValidatorPledges(model.BlockGetValidatorsRequest{})
+	if resData.Result.LedgerSeq != 3 || resData.Result.Validators[0].Address != alice || resData.Result.Validators[1].Address != carol {
+		t.Errorf("wrong latest validators %+v", resData.Result)
+	}
+}
+
+func Test_ValidatorTracker(t *testing.T) {
+	server := validatorNode()
+	defer server.Close()
+	tracker := blockchain.ValidatorTracker{Url: server.URL}
+	for seq, hash := range []string{"a", "a", "b"} {
+		err := tracker.Apply(model.GetInfoHeader{Number: int64(seq + 1), ValidatorsHash: hash}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(tracker.Changes) != 1 {
+		t.Fatalf("wrong changes %+v", tracker.Changes)
+	}
+	change := tracker.Changes[0]
+	if change.LedgerSeq != 3 || len(change.Added) != 1 || change.Added[0].Address != carol ||
+		len(change.Removed) != 1 || change.Removed[0].Address != bob ||
+		len(change.Changed) != 1 || change.Changed[0].PledgeCoinAmount != 200 {
+		t.Errorf("wrong change %+v", change)
+	}
+	if len(tracker.Validators()) != 2 || tracker.Validators()[0].Address != alice {
+		t.Errorf("wrong validators %+v", tracker.Validators())
+	}
+}
+
+func Test_RewardHistory(t *testing.T) {
+	server := validatorNode()
+	defer server.Close()
+	block := blockchain.BlockOperation{Url: server.URL}
+	resData := block.GetRewardSample()
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	sample := resData.Result
+	if sample.LedgerSeq != 3 || len(sample.Validators) != 2 || sample.Validators[0].Address != alice || sample.Validators[0].Reward != 50 || sample.Kols[0].Reward != 5 {
+		t.Fatalf("wrong sample %+v", sample)
+	}
+
+	history := blockchain.NewRewardHistory()
+	history.Add(sample)
+	history.Add(model.RewardSample{LedgerSeq: 13, Validators: []model.AddressReward{{Address: alice, Reward: 90}, {Address: carol, Reward: 10}}})
+	// out of order, and replaced by the later sample of the same ledger
+	history.Add(model.RewardSample{LedgerSeq: 8, Validators: []model.AddressReward{{Address: alice, Reward: 1}}})
+	history.Add(model.RewardSample{LedgerSeq: 8, Validators: []model.AddressReward{{Address: alice, Reward: 60}, {Address: carol, Reward: 80}}})
+	if samples := history.Samples(4, 0); len(samples) != 2 || samples[0].LedgerSeq != 8 {
+		t.Errorf("wrong samples %+v", samples)
+	}
+
+	// alice earns 10 then 30; carol 10 then withdraws and earns 10
+	report := history.Report(0, 13)
+	if report.Samples != 3 || report.StartSeq != 3 || report.EndSeq != 13 || report.ValidatorTotal != 60 || report.ValidatorAverage != 6 {
+		t.Fatalf("wrong report %+v", report)
+	}
+	if report.Validators[0].Address != alice || report.Validators[0].Reward != 40 || report.Validators[0].AveragePerLedger != 4 || report.Validators[1].Reward != 20 {
+		t.Errorf("wrong validator rewards %+v", report.Validators)
+	}
+	if report.KolTotal != 0 || len(report.Kols) != 1 {
+		t.Errorf("wrong kol rewards %+v", report.Kols)
+	}
+	if report = history.Report(20, 30); report.Samples != 0 || len(report.Validators) != 0 {
+		t.Errorf("empty range is reported %+v", report)
+	}
+
+	// carol is missing from ledger 18, so ledger 23 is counted from ledger 13
+	history.Add(model.RewardSample{LedgerSeq: 18, Validators: []model.AddressReward{{Address: alice, Reward: 95}}})
+	history.Add(model.RewardSample{LedgerSeq: 23, Validators: []model.AddressReward{{Address: alice, Reward: 100}, {Address: carol, Reward: 25}}})
+	report = history.Report(13, 23)
+	if report.ValidatorTotal != 25 || report.Validators[0].Address != carol || report.Validators[0].Reward != 15 || report.Validators[1].Reward != 10 {
+		t.Errorf("wrong rewards across a missing sample %+v", report.Validators)
+	}
+}
+
+func Test_GetRewardSampleInvalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getLedger":
+			w.Write([]byte(`{"error_code":0,"result":{"header":{"seq":3}}}`))
+		case "/callContract":
+			w.Write([]byte(`{"error_code":0,"result":{"query_rets":[{"result":{"value":"{\"rewards\":{\"validators\":{\"` + alice + `\":[\"5x\",\"0\"]},\"kols\":{}}}"}}]}}`))
+		}
+	}))
+	defer server.Close()
+	block := blockchain.BlockOperation{Url: server.URL}
+	resData := block.GetRewardSample()
+	if resData.ErrorCode != exception.SYSTEM_ERROR || !strings.Contains(resData.ErrorDesc, alice) {
+		t.Errorf("invalid reward is accepted %d %s", resData.ErrorCode, resData.ErrorDesc)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"net/url"
 	"strconv"
 
-	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
 	"github.com/bumoproject/bumo-sdk-go/src/exception"
 	"github.com/bumoproject/bumo-sdk-go/src/model"
 )
@@ -118,12 +117,12 @@ func GetLatestFees(url string) (int64, int64, exception.SDKResponse) {
 	}
 }
 
-func GetCallDataStr(funcstr string, ContractAddress string, TokenOwner string) (string, exception.SDKResponse) {
-	if !keypair.CheckAddress(ContractAddress) {
+func GetCallDataStr(funcstr string, ContractAddress string, TokenOwner string, network model.Network) (string, exception.SDKResponse) {
+	if !network.CheckAddress(ContractAddress) {
 		return "", exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
 	}
 	if TokenOwner != "" {
-		if !keypair.CheckAddress(TokenOwner) {
+		if !network.CheckAddress(TokenOwner) {
 			return "", exception.GetSDKRes(exception.INVALID_TOKENOWNER_ERROR)
 		}
 	}
@@ -146,9 +145,9 @@ func GetCallDataStr(funcstr string, ContractAddress string, TokenOwner string) (
 
 }
 
-func CheckActivated(address string, url string) (bool, exception.SDKResponse) {
+func CheckActivated(address string, url string, network model.Network) (bool, exception.SDKResponse) {
 	var resData model.AccountGetInfoResponse
-	if !keypair.CheckAddress(address) {
+	if !network.CheckAddress(address) {
 		return false, exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
 	}
 	response, SDKRes := GetRequest(url, "/getAccount?address=", address)
@@ -176,3 +175,34 @@ func CheckActivated(address string, url string) (bool, exception.SDKResponse) {
 		return false, exception.GetSDKRes(exception.CONNECTNETWORK_ERROR)
 	}
 }
+
+// CheckContract reports whether the address is an activated contract account
+func CheckContract(address string, url string, network model.Network) (bool, exception.SDKResponse) {
+	var resData model.AccountGetInfoResponse
+	if !network.CheckAddress(address) {
+		return false, exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
+	}
+	response, SDKRes := GetRequest(url, "/getAccount?address=", address)
+	if SDKRes.ErrorCode != 0 {
+		return false, SDKRes
+	}
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		return false, exception.GetSDKRes(exception.CONNECTNETWORK_ERROR)
+	}
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+	err := decoder.Decode(&resData)
+	if err != nil {
+		return false, exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	if resData.ErrorCode == 4 {
+		return false, exception.GetSDKRes(exception.SUCCESS)
+	}
+	if resData.ErrorCode != 0 {
+		SDKRes.ErrorCode = resData.ErrorCode
+		SDKRes.ErrorDesc = resData.ErrorDesc
+		return false, SDKRes
+	}
+	return resData.Result.Contract.Payload != "", exception.GetSDKRes(exception.SUCCESS)
+}
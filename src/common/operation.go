@@ -7,7 +7,6 @@ import (
 	"math"
 	"strconv"
 
-	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
 	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
 	"github.com/bumoproject/bumo-sdk-go/src/exception"
 	"github.com/bumoproject/bumo-sdk-go/src/model"
@@ -18,7 +17,7 @@ const (
 )
 
 //GetOperations
-func GetOperations(operationsList list.List, url string, sourceAddress string) ([]*protocol.Operation, exception.SDKResponse) {
+func GetOperations(operationsList list.List, url string, sourceAddress string, network model.Network) ([]*protocol.Operation, exception.SDKResponse) {
 	var operations []*protocol.Operation
 	for e := operationsList.Front(); e != nil; e = e.Next() {
 		operationsData, ok := e.Value.(model.BaseOperation)
@@ -36,7 +35,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if operationsReqData.GetDestAddress() == sourceAddress && sourceAddress != "" {
 				return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_DESTADDRESS_ERROR)
 			}
-			operationsResData := Activate(operationsReqData, url)
+			operationsResData := Activate(operationsReqData, url, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -46,7 +45,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if !ok {
 				return operations, exception.GetSDKRes(exception.OPERATIONS_ONE_ERROR)
 			}
-			operationsResData := SetMetadata(operationsReqData)
+			operationsResData := SetMetadata(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -56,7 +55,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if !ok {
 				return operations, exception.GetSDKRes(exception.OPERATIONS_ONE_ERROR)
 			}
-			operationsResData := SetPrivilege(operationsReqData)
+			operationsResData := SetPrivilege(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -66,7 +65,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if !ok {
 				return operations, exception.GetSDKRes(exception.OPERATIONS_ONE_ERROR)
 			}
-			operationsResData := AssetIssue(operationsReqData)
+			operationsResData := AssetIssue(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -79,7 +78,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if operationsReqData.GetDestAddress() == sourceAddress && sourceAddress != "" {
 				return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_DESTADDRESS_ERROR)
 			}
-			operationsResData := AssetSend(operationsReqData)
+			operationsResData := AssetSend(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -92,7 +91,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if operationsReqData.GetDestAddress() == sourceAddress && sourceAddress != "" {
 				return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_DESTADDRESS_ERROR)
 			}
-			operationsResData := BUSend(operationsReqData)
+			operationsResData := BUSend(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -102,7 +101,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if !ok {
 				return operations, exception.GetSDKRes(exception.OPERATIONS_ONE_ERROR)
 			}
-			operationsResData := Ctp10TokenIssue(operationsReqData)
+			operationsResData := Ctp10TokenIssue(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -117,7 +116,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 					return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR)
 				}
 			}
-			operationsResData := Transfer(operationsReqData)
+			operationsResData := Transfer(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -132,7 +131,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 					return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR)
 				}
 			}
-			operationsResData := TransferFrom(operationsReqData)
+			operationsResData := TransferFrom(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -147,7 +146,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 					return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR)
 				}
 			}
-			operationsResData := Approve(operationsReqData)
+			operationsResData := Approve(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -162,7 +161,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 					return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR)
 				}
 			}
-			operationsResData := Assign(operationsReqData)
+			operationsResData := Assign(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -177,7 +176,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 					return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR)
 				}
 			}
-			operationsResData := ChangeOwner(operationsReqData)
+			operationsResData := ChangeOwner(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -187,7 +186,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if !ok {
 				return operations, exception.GetSDKRes(exception.OPERATIONS_ONE_ERROR)
 			}
-			operationsResData := ContractCreate(operationsReqData)
+			operationsResData := ContractCreate(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -202,7 +201,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 					return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR)
 				}
 			}
-			operationsResData := InvokeByAsset(operationsReqData)
+			operationsResData := InvokeByAsset(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -217,7 +216,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 					return operations, exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR)
 				}
 			}
-			operationsResData := InvokeByBU(operationsReqData)
+			operationsResData := InvokeByBU(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -227,7 +226,7 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 			if !ok {
 				return operations, exception.GetSDKRes(exception.OPERATIONS_ONE_ERROR)
 			}
-			operationsResData := LogCreate(operationsReqData)
+			operationsResData := LogCreate(operationsReqData, network)
 			if operationsResData.ErrorCode != 0 {
 				return operations, exception.GetSDKRes(operationsResData.ErrorCode)
 			}
@@ -239,17 +238,17 @@ func GetOperations(operationsList list.List, url string, sourceAddress string) (
 	return operations, exception.GetSDKRes(exception.SUCCESS)
 }
 
-//activate the account
-func Activate(reqData model.AccountActivateOperation, url string) model.AccountActivateResponse {
+//activate the account, the url is not used so the operation can be built offline
+func Activate(reqData model.AccountActivateOperation, url string, network model.Network) model.AccountActivateResponse {
 	var resData model.AccountActivateResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			resData.ErrorCode = exception.INVALID_SOURCEADDRESS_ERROR
 			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 			return resData
 		}
 	}
-	if !keypair.CheckAddress(reqData.GetDestAddress()) {
+	if !network.CheckAddress(reqData.GetDestAddress()) {
 		resData.ErrorCode = exception.INVALID_DESTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -264,6 +263,35 @@ func Activate(reqData model.AccountActivateOperation, url string) model.AccountA
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
+	Priv := &protocol.AccountPrivilege{
+		MasterWeight: 1,
+		Thresholds: &protocol.AccountThreshold{
+			TxThreshold: 1,
+		},
+	}
+	if reqData.GetPriv() != nil {
+		var SDKRes exception.SDKResponse
+		Priv, SDKRes = activatePriv(*reqData.GetPriv(), network)
+		if SDKRes.ErrorCode != 0 {
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+	}
+	Metadatas := make([]*protocol.KeyPair, len(reqData.GetAccountMetadatas()))
+	for i, metadata := range reqData.GetAccountMetadatas() {
+		if len(metadata.Key) <= 0 || len(metadata.Key) > 1024 {
+			resData.ErrorCode = exception.INVALID_DATAKEY_ERROR
+			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
+			return resData
+		}
+		if len(metadata.Value) > (1024 * 256) {
+			resData.ErrorCode = exception.INVALID_DATAVALUE_ERROR
+			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
+			return resData
+		}
+		Metadatas[i] = &protocol.KeyPair{Key: metadata.Key, Value: metadata.Value}
+	}
 	Operations := []*protocol.Operation{
 		{
 			SourceAddress: reqData.GetSourceAddress(),
@@ -271,12 +299,8 @@ func Activate(reqData model.AccountActivateOperation, url string) model.AccountA
 			Type:          protocol.Operation_CREATE_ACCOUNT,
 			CreateAccount: &protocol.OperationCreateAccount{
 				DestAddress: reqData.GetDestAddress(),
-				Priv: &protocol.AccountPrivilege{
-					MasterWeight: 1,
-					Thresholds: &protocol.AccountThreshold{
-						TxThreshold: 1,
-					},
-				},
+				Priv:        Priv,
+				Metadatas:   Metadatas,
 				InitBalance: reqData.GetInitBalance(),
 			},
 		},
@@ -286,11 +310,52 @@ func Activate(reqData model.AccountActivateOperation, url string) model.AccountA
 
 }
 
+//the privilege of a new account, checked as SetPrivilege checks it
+func activatePriv(priv model.Priv, network model.Network) (*protocol.AccountPrivilege, exception.SDKResponse) {
+	if priv.MasterWeight < 0 || priv.MasterWeight > math.MaxUint32 {
+		return nil, exception.GetSDKRes(exception.INVALID_MASTERWEIGHT_ERROR)
+	}
+	Signers := make([]*protocol.Signer, len(priv.Signers))
+	for i, signer := range priv.Signers {
+		if !network.CheckAddress(signer.Address) {
+			return nil, exception.GetSDKRes(exception.INVALID_SIGNER_ADDRESS_ERROR)
+		}
+		if signer.Weight > math.MaxUint32 || signer.Weight < 0 {
+			return nil, exception.GetSDKRes(exception.INVALID_SIGNER_WEIGHT_ERROR)
+		}
+		Signers[i] = &protocol.Signer{Address: signer.Address, Weight: signer.Weight}
+	}
+	if priv.Thresholds.TxThreshold < 0 {
+		return nil, exception.GetSDKRes(exception.INVALID_TX_THRESHOLD_ERROR)
+	}
+	TypeThresholds := make([]*protocol.OperationTypeThreshold, len(priv.Thresholds.TypeThresholds))
+	for i, typeThreshold := range priv.Thresholds.TypeThresholds {
+		if typeThreshold.Type > 100 || typeThreshold.Type <= 0 {
+			return nil, exception.GetSDKRes(exception.INVALID_TYPETHRESHOLD_TYPE_ERROR)
+		}
+		if typeThreshold.Threshold < 0 {
+			return nil, exception.GetSDKRes(exception.INVALID_TYPE_THRESHOLD_ERROR)
+		}
+		TypeThresholds[i] = &protocol.OperationTypeThreshold{
+			Type:      (protocol.Operation_Type)(typeThreshold.Type),
+			Threshold: typeThreshold.Threshold,
+		}
+	}
+	return &protocol.AccountPrivilege{
+		MasterWeight: priv.MasterWeight,
+		Signers:      Signers,
+		Thresholds: &protocol.AccountThreshold{
+			TxThreshold:    priv.Thresholds.TxThreshold,
+			TypeThresholds: TypeThresholds,
+		},
+	}, exception.GetSDKRes(exception.SUCCESS)
+}
+
 //set metadata
-func SetMetadata(reqData model.AccountSetMetadataOperation) model.AccountSetMetadataResponse {
+func SetMetadata(reqData model.AccountSetMetadataOperation, network model.Network) model.AccountSetMetadataResponse {
 	var resData model.AccountSetMetadataResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
 			resData.ErrorCode = SDKRes.ErrorCode
 			resData.ErrorDesc = SDKRes.ErrorDesc
@@ -333,10 +398,10 @@ func SetMetadata(reqData model.AccountSetMetadataOperation) model.AccountSetMeta
 }
 
 //set privilege
-func SetPrivilege(reqData model.AccountSetPrivilegeOperation) model.AccountSetPrivilegeResponse {
+func SetPrivilege(reqData model.AccountSetPrivilegeOperation, network model.Network) model.AccountSetPrivilegeResponse {
 	var resData model.AccountSetPrivilegeResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
 			resData.ErrorCode = SDKRes.ErrorCode
 			resData.ErrorDesc = SDKRes.ErrorDesc
@@ -353,7 +418,7 @@ func SetPrivilege(reqData model.AccountSetPrivilegeOperation) model.AccountSetPr
 		}
 	}
 	for i := range reqData.GetSigners() {
-		if !keypair.CheckAddress(reqData.GetSigners()[i].Address) {
+		if !network.CheckAddress(reqData.GetSigners()[i].Address) {
 			SDKRes := exception.GetSDKRes(exception.INVALID_SIGNER_ADDRESS_ERROR)
 			resData.ErrorCode = SDKRes.ErrorCode
 			resData.ErrorDesc = SDKRes.ErrorDesc
@@ -419,10 +484,10 @@ func SetPrivilege(reqData model.AccountSetPrivilegeOperation) model.AccountSetPr
 }
 
 //asset issue
-func AssetIssue(reqData model.AssetIssueOperation) model.AssetIssueResponse {
+func AssetIssue(reqData model.AssetIssueOperation, network model.Network) model.AssetIssueResponse {
 	var resData model.AssetIssueResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			resData.ErrorCode = exception.INVALID_SOURCEADDRESS_ERROR
 			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 			return resData
@@ -455,9 +520,9 @@ func AssetIssue(reqData model.AssetIssueOperation) model.AssetIssueResponse {
 }
 
 //asset send
-func AssetSend(reqData model.AssetSendOperation) model.AssetSendResponse {
+func AssetSend(reqData model.AssetSendOperation, network model.Network) model.AssetSendResponse {
 	var resData model.AssetSendResponse
-	if !keypair.CheckAddress(reqData.GetIssuer()) {
+	if !network.CheckAddress(reqData.GetIssuer()) {
 		resData.ErrorCode = exception.INVALID_ISSUER_ADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -472,7 +537,7 @@ func AssetSend(reqData model.AssetSendOperation) model.AssetSendResponse {
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetDestAddress()) {
+	if !network.CheckAddress(reqData.GetDestAddress()) {
 		SDKRes := exception.GetSDKRes(exception.INVALID_DESTADDRESS_ERROR)
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -490,7 +555,7 @@ func AssetSend(reqData model.AssetSendOperation) model.AssetSendResponse {
 	data.SetCode(reqData.GetCode())
 	data.SetIssuer(reqData.GetIssuer())
 	data.SetMetadata(reqData.GetMetadata())
-	contractData := InvokeByAsset(data)
+	contractData := InvokeByAsset(data, network)
 	if contractData.ErrorCode != 0 {
 		resData.ErrorCode = contractData.ErrorCode
 		resData.ErrorDesc = contractData.ErrorDesc
@@ -502,7 +567,7 @@ func AssetSend(reqData model.AssetSendOperation) model.AssetSendResponse {
 }
 
 //bu send
-func BUSend(reqData model.BUSendOperation) model.BUSendResponse {
+func BUSend(reqData model.BUSendOperation, network model.Network) model.BUSendResponse {
 	var resData model.BUSendResponse
 	if reqData.GetAmount() < 0 {
 		SDKRes := exception.GetSDKRes(exception.INVALID_BU_AMOUNT_ERROR)
@@ -510,7 +575,7 @@ func BUSend(reqData model.BUSendOperation) model.BUSendResponse {
 		resData.ErrorDesc = SDKRes.ErrorDesc
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetDestAddress()) {
+	if !network.CheckAddress(reqData.GetDestAddress()) {
 		SDKRes := exception.GetSDKRes(exception.INVALID_DESTADDRESS_ERROR)
 		resData.ErrorCode = SDKRes.ErrorCode
 		resData.ErrorDesc = SDKRes.ErrorDesc
@@ -526,7 +591,7 @@ func BUSend(reqData model.BUSendOperation) model.BUSendResponse {
 	data.SetContractAddress(reqData.GetDestAddress())
 	data.SetAmount(reqData.GetAmount())
 	data.SetMetadata(reqData.GetMetadata())
-	contractData := InvokeByBU(data)
+	contractData := InvokeByBU(data, network)
 	if contractData.ErrorCode != 0 {
 		resData.ErrorCode = contractData.ErrorCode
 		resData.ErrorDesc = contractData.ErrorDesc
@@ -538,10 +603,10 @@ func BUSend(reqData model.BUSendOperation) model.BUSendResponse {
 }
 
 //Ctp10token
-func Ctp10TokenIssue(reqData model.Ctp10TokenIssueOperation) model.Ctp10TokenIssueResponse {
+func Ctp10TokenIssue(reqData model.Ctp10TokenIssueOperation, network model.Network) model.Ctp10TokenIssueResponse {
 	var resData model.Ctp10TokenIssueResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			resData.ErrorCode = exception.INVALID_SOURCEADDRESS_ERROR
 			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 			return resData
@@ -608,9 +673,9 @@ func Ctp10TokenIssue(reqData model.Ctp10TokenIssueOperation) model.Ctp10TokenIss
 }
 
 //Ctp10token
-func Transfer(reqData model.Ctp10TokenTransferOperation) model.Ctp10TokenTransferResponse {
+func Transfer(reqData model.Ctp10TokenTransferOperation, network model.Network) model.Ctp10TokenTransferResponse {
 	var resData model.Ctp10TokenTransferResponse
-	if !keypair.CheckAddress(reqData.GetDestAddress()) {
+	if !network.CheckAddress(reqData.GetDestAddress()) {
 		resData.ErrorCode = exception.INVALID_DESTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -646,7 +711,7 @@ func Transfer(reqData model.Ctp10TokenTransferOperation) model.Ctp10TokenTransfe
 	data.SetAmount(0)
 	data.SetInput(string(InputStr))
 	data.SetMetadata(reqData.GetMetadata())
-	contractData := InvokeByBU(data)
+	contractData := InvokeByBU(data, network)
 	if contractData.ErrorCode != 0 {
 		resData.ErrorCode = contractData.ErrorCode
 		resData.ErrorDesc = contractData.ErrorDesc
@@ -658,14 +723,14 @@ func Transfer(reqData model.Ctp10TokenTransferOperation) model.Ctp10TokenTransfe
 }
 
 //Ctp10token
-func TransferFrom(reqData model.Ctp10TokenTransferFromOperation) model.Ctp10TokenTransferFromResponse {
+func TransferFrom(reqData model.Ctp10TokenTransferFromOperation, network model.Network) model.Ctp10TokenTransferFromResponse {
 	var resData model.Ctp10TokenTransferFromResponse
-	if !keypair.CheckAddress(reqData.GetDestAddress()) {
+	if !network.CheckAddress(reqData.GetDestAddress()) {
 		resData.ErrorCode = exception.INVALID_DESTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetFromAddress()) {
+	if !network.CheckAddress(reqData.GetFromAddress()) {
 		resData.ErrorCode = exception.INVALID_FROMADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -697,7 +762,7 @@ func TransferFrom(reqData model.Ctp10TokenTransferFromOperation) model.Ctp10Toke
 	data.SetAmount(0)
 	data.SetInput(string(InputStr))
 	data.SetMetadata(reqData.GetMetadata())
-	contractData := InvokeByBU(data)
+	contractData := InvokeByBU(data, network)
 	if contractData.ErrorCode != 0 {
 		resData.ErrorCode = contractData.ErrorCode
 		resData.ErrorDesc = contractData.ErrorDesc
@@ -709,9 +774,9 @@ func TransferFrom(reqData model.Ctp10TokenTransferFromOperation) model.Ctp10Toke
 }
 
 //Ctp10token
-func Approve(reqData model.Ctp10TokenApproveOperation) model.Ctp10TokenApproveResponse {
+func Approve(reqData model.Ctp10TokenApproveOperation, network model.Network) model.Ctp10TokenApproveResponse {
 	var resData model.Ctp10TokenApproveResponse
-	if !keypair.CheckAddress(reqData.GetSpender()) {
+	if !network.CheckAddress(reqData.GetSpender()) {
 		resData.ErrorCode = exception.INVALID_SPENDER_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -742,7 +807,7 @@ func Approve(reqData model.Ctp10TokenApproveOperation) model.Ctp10TokenApproveRe
 	data.SetAmount(0)
 	data.SetInput(string(InputStr))
 	data.SetMetadata(reqData.GetMetadata())
-	contractData := InvokeByBU(data)
+	contractData := InvokeByBU(data, network)
 	if contractData.ErrorCode != 0 {
 		resData.ErrorCode = contractData.ErrorCode
 		resData.ErrorDesc = contractData.ErrorDesc
@@ -754,14 +819,14 @@ func Approve(reqData model.Ctp10TokenApproveOperation) model.Ctp10TokenApproveRe
 }
 
 //Ctp10token
-func Assign(reqData model.Ctp10TokenAssignOperation) model.Ctp10TokenAssignResponse {
+func Assign(reqData model.Ctp10TokenAssignOperation, network model.Network) model.Ctp10TokenAssignResponse {
 	var resData model.Ctp10TokenAssignResponse
-	if !keypair.CheckAddress(reqData.GetDestAddress()) {
+	if !network.CheckAddress(reqData.GetDestAddress()) {
 		resData.ErrorCode = exception.INVALID_DESTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetContractAddress()) {
+	if !network.CheckAddress(reqData.GetContractAddress()) {
 		resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -798,7 +863,7 @@ func Assign(reqData model.Ctp10TokenAssignOperation) model.Ctp10TokenAssignRespo
 	data.SetAmount(0)
 	data.SetInput(string(InputStr))
 	data.SetMetadata(reqData.GetMetadata())
-	contractData := InvokeByBU(data)
+	contractData := InvokeByBU(data, network)
 	if contractData.ErrorCode != 0 {
 		resData.ErrorCode = contractData.ErrorCode
 		resData.ErrorDesc = contractData.ErrorDesc
@@ -810,9 +875,9 @@ func Assign(reqData model.Ctp10TokenAssignOperation) model.Ctp10TokenAssignRespo
 }
 
 //Ctp10token
-func ChangeOwner(reqData model.Ctp10TokenChangeOwnerOperation) model.Ctp10TokenChangeOwnerResponse {
+func ChangeOwner(reqData model.Ctp10TokenChangeOwnerOperation, network model.Network) model.Ctp10TokenChangeOwnerResponse {
 	var resData model.Ctp10TokenChangeOwnerResponse
-	if !keypair.CheckAddress(reqData.GetTokenOwner()) {
+	if !network.CheckAddress(reqData.GetTokenOwner()) {
 		resData.ErrorCode = exception.INVALID_TOKENOWNER_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -826,7 +891,7 @@ func ChangeOwner(reqData model.Ctp10TokenChangeOwnerOperation) model.Ctp10TokenC
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetContractAddress()) {
+	if !network.CheckAddress(reqData.GetContractAddress()) {
 		resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -842,7 +907,7 @@ func ChangeOwner(reqData model.Ctp10TokenChangeOwnerOperation) model.Ctp10TokenC
 	data.SetAmount(0)
 	data.SetInput(string(InputStr))
 	data.SetMetadata(reqData.GetMetadata())
-	contractData := InvokeByBU(data)
+	contractData := InvokeByBU(data, network)
 	if contractData.ErrorCode != 0 {
 		resData.ErrorCode = contractData.ErrorCode
 		resData.ErrorDesc = contractData.ErrorDesc
@@ -854,10 +919,10 @@ func ChangeOwner(reqData model.Ctp10TokenChangeOwnerOperation) model.Ctp10TokenC
 }
 
 //contract create
-func ContractCreate(reqData model.ContractCreateOperation) model.ContractCreateResponse {
+func ContractCreate(reqData model.ContractCreateOperation, network model.Network) model.ContractCreateResponse {
 	var resData model.ContractCreateResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
 			resData.ErrorCode = SDKRes.ErrorCode
 			resData.ErrorDesc = SDKRes.ErrorDesc
@@ -901,17 +966,17 @@ func ContractCreate(reqData model.ContractCreateOperation) model.ContractCreateR
 }
 
 //invoke by asset
-func InvokeByAsset(reqData model.ContractInvokeByAssetOperation) model.ContractInvokeByBUResponse {
+func InvokeByAsset(reqData model.ContractInvokeByAssetOperation, network model.Network) model.ContractInvokeByBUResponse {
 	var resData model.ContractInvokeByBUResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			SDKRes := exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
 			resData.ErrorCode = SDKRes.ErrorCode
 			resData.ErrorDesc = SDKRes.ErrorDesc
 			return resData
 		}
 	}
-	if !keypair.CheckAddress(reqData.GetContractAddress()) {
+	if !network.CheckAddress(reqData.GetContractAddress()) {
 		resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -932,7 +997,7 @@ func InvokeByAsset(reqData model.ContractInvokeByAssetOperation) model.ContractI
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if reqData.GetIssuer() != "" && !keypair.CheckAddress(reqData.GetIssuer()) {
+	if reqData.GetIssuer() != "" && !network.CheckAddress(reqData.GetIssuer()) {
 		resData.ErrorCode = exception.INVALID_ISSUER_ADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -970,16 +1035,16 @@ func InvokeByAsset(reqData model.ContractInvokeByAssetOperation) model.ContractI
 }
 
 //invoke by bu
-func InvokeByBU(reqData model.ContractInvokeByBUOperation) model.ContractInvokeByBUResponse {
+func InvokeByBU(reqData model.ContractInvokeByBUOperation, network model.Network) model.ContractInvokeByBUResponse {
 	var resData model.ContractInvokeByBUResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			resData.ErrorCode = exception.INVALID_SOURCEADDRESS_ERROR
 			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 			return resData
 		}
 	}
-	if !keypair.CheckAddress(reqData.GetContractAddress()) {
+	if !network.CheckAddress(reqData.GetContractAddress()) {
 		resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -1012,10 +1077,10 @@ func InvokeByBU(reqData model.ContractInvokeByBUOperation) model.ContractInvokeB
 }
 
 //log create
-func LogCreate(reqData model.LogCreateOperation) model.LogCreateResponse {
+func LogCreate(reqData model.LogCreateOperation, network model.Network) model.LogCreateResponse {
 	var resData model.LogCreateResponse
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !network.CheckAddress(reqData.GetSourceAddress()) {
 			resData.ErrorCode = exception.INVALID_SOURCEADDRESS_ERROR
 			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 			return resData
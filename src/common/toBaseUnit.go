@@ -11,12 +11,23 @@ const buToMoDecimals int64 = 8
 const maxLen = 21
 const maxDecimals = 18
 
+// BU2MO returns "" when amount is not valid.
+//
+// Deprecated: use model.ParseBU, which reports why an amount is not valid.
 func BU2MO(amount string) string {
 	return UnitWithDecimals(amount, 8)
 }
+
+// MO2BU returns "" when amount is not valid.
+//
+// Deprecated: use model.NewBUAmount(mo).String().
 func MO2BU(amount string) string {
 	return UnitWithoutDecimals(amount, 8)
 }
+
+// UnitWithDecimals returns "" when amount is not valid.
+//
+// Deprecated: use model.ParseAmount, which reports why an amount is not valid.
 func UnitWithDecimals(amount string, decimals int) string {
 	if decimals > maxDecimals || decimals < 0 {
 		return ""
@@ -65,6 +76,9 @@ func UnitWithDecimals(amount string, decimals int) string {
 	return amount
 }
 
+// UnitWithoutDecimals returns "" when amount is not valid.
+//
+// Deprecated: use model.NewAmount(units, decimals).String().
 func UnitWithoutDecimals(amount string, decimals int) string {
 	_, err := strconv.ParseInt(amount, 10, 64)
 	if err != nil {
@@ -0,0 +1,382 @@
+// abi
+package contract
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// parameter types of a contract interface
+const (
+	// a JSON string
+	TYPE_STRING string = "string"
+	// a JSON string holding an account address
+	TYPE_ADDRESS string = "address"
+	// a JSON boolean
+	TYPE_BOOL string = "bool"
+	// a JSON number that fits in int64
+	TYPE_INT64 string = "int64"
+	// an int64 written as a JSON string, as the stoI64Check of contracts expects
+	TYPE_INT64_STRING string = "int64string"
+	// any JSON value
+	TYPE_OBJECT string = "object"
+)
+
+// ContractParam is a parameter of a contract method, or a field of the object a
+// query method returns.
+type ContractParam struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// the decimals of the token an int64 or int64string amount is counted in;
+	// only parameters with decimals take model.Amount arguments
+	Decimals *int `json:"decimals,omitempty"`
+}
+
+// ContractMethod describes a method of a contract. Query methods are run by
+// the query function of the contract through ContractOperation.Call, the
+// others by the main function through an invocation.
+type ContractMethod struct {
+	Name    string          `json:"name"`
+	Query   bool            `json:"query"`
+	Inputs  []ContractParam `json:"inputs"`
+	Outputs []ContractParam `json:"outputs"`
+}
+
+// ContractEvent describes the logs a contract writes with tlog: the topic is
+// the name and the datas are the inputs, in order.
+type ContractEvent struct {
+	Name   string          `json:"name"`
+	Inputs []ContractParam `json:"inputs"`
+}
+
+// ContractInterface describes the methods and events of a contract. Contracts
+// take their input as {"method": name, "params": {input name: value}} and
+// queries return an object with the outputs as fields.
+type ContractInterface struct {
+	Name    string           `json:"name"`
+	Methods []ContractMethod `json:"methods"`
+	Events  []ContractEvent  `json:"events,omitempty"`
+}
+
+// LoadInterface reads a contract interface from JSON and checks it.
+func LoadInterface(data []byte) (ContractInterface, exception.SDKResponse) {
+	var contractInterface ContractInterface
+	err := json.Unmarshal(data, &contractInterface)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.INVALID_CONTRACT_INTERFACE_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return contractInterface, SDKRes
+	}
+	return contractInterface, contractInterface.Check()
+}
+
+// Check reports methods and events without a name or duplicated, and unknown
+// types.
+func (contractInterface ContractInterface) Check() exception.SDKResponse {
+	names := make(map[string]bool)
+	for _, method := range contractInterface.Methods {
+		if method.Name == "" || names[method.Name] {
+			return interfaceError("method name " + strconv.Quote(method.Name))
+		}
+		names[method.Name] = true
+		SDKRes := checkParams(method.Name, append(append([]ContractParam{}, method.Inputs...), method.Outputs...))
+		if SDKRes.ErrorCode != 0 {
+			return SDKRes
+		}
+	}
+	events := make(map[string]bool)
+	for _, event := range contractInterface.Events {
+		if event.Name == "" || events[event.Name] {
+			return interfaceError("event name " + strconv.Quote(event.Name))
+		}
+		events[event.Name] = true
+		SDKRes := checkParams(event.Name, event.Inputs)
+		if SDKRes.ErrorCode != 0 {
+			return SDKRes
+		}
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+func checkParams(name string, params []ContractParam) exception.SDKResponse {
+	for _, param := range params {
+		if param.Name == "" {
+			return interfaceError(name + " has a parameter without name")
+		}
+		switch param.Type {
+		case TYPE_STRING, TYPE_ADDRESS, TYPE_BOOL, TYPE_INT64, TYPE_INT64_STRING, TYPE_OBJECT:
+		default:
+			return interfaceError(name + "." + param.Name + " has unknown type " + strconv.Quote(param.Type))
+		}
+		if param.Decimals != nil {
+			if param.Type != TYPE_INT64 && param.Type != TYPE_INT64_STRING {
+				return interfaceError(name + "." + param.Name + " of type " + param.Type + " has decimals")
+			}
+			if *param.Decimals < 0 || *param.Decimals > model.MAX_AMOUNT_DECIMALS {
+				return interfaceError(name + "." + param.Name + " has invalid decimals")
+			}
+		}
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+// Event finds an event by name.
+func (contractInterface ContractInterface) Event(name string) (ContractEvent, bool) {
+	for _, event := range contractInterface.Events {
+		if event.Name == name {
+			return event, true
+		}
+	}
+	return ContractEvent{}, false
+}
+
+func interfaceError(desc string) exception.SDKResponse {
+	SDKRes := exception.GetSDKRes(exception.INVALID_CONTRACT_INTERFACE_ERROR)
+	SDKRes.ErrorDesc += ": " + desc
+	return SDKRes
+}
+
+// Method finds a method by name.
+func (contractInterface ContractInterface) Method(name string) (ContractMethod, exception.SDKResponse) {
+	for _, method := range contractInterface.Methods {
+		if method.Name == name {
+			return method, exception.GetSDKRes(exception.SUCCESS)
+		}
+	}
+	SDKRes := exception.GetSDKRes(exception.CONTRACT_METHOD_NOT_FOUND_ERROR)
+	SDKRes.ErrorDesc += ": " + name
+	return ContractMethod{}, SDKRes
+}
+
+// EncodeInput builds the contract input of a call with one argument per input
+// of the method, in order. Strings and addresses take a string, bools a bool,
+// int64 and int64string an integer type, int64string also a decimal string,
+// and both a model.Amount when they have decimals, rescaled to them.
+// Addresses are checked against the prefix of network.
+func (method ContractMethod) EncodeInput(network model.Network, args ...interface{}) (string, exception.SDKResponse) {
+	if len(args) != len(method.Inputs) {
+		return "", argumentError(method.Name + " takes " + strconv.Itoa(len(method.Inputs)) + " arguments")
+	}
+	params := make(map[string]interface{}, len(args))
+	for i, param := range method.Inputs {
+		value, ok := encodeValue(param, args[i], network)
+		if !ok {
+			return "", argumentError(method.Name + "." + param.Name + " must be " + param.Type)
+		}
+		params[param.Name] = value
+	}
+	input := map[string]interface{}{"method": method.Name}
+	if len(params) != 0 {
+		input["params"] = params
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", argumentError(err.Error())
+	}
+	return string(data), exception.GetSDKRes(exception.SUCCESS)
+}
+
+func argumentError(desc string) exception.SDKResponse {
+	SDKRes := exception.GetSDKRes(exception.INVALID_CONTRACT_ARGUMENT_ERROR)
+	SDKRes.ErrorDesc += ": " + desc
+	return SDKRes
+}
+
+// toInt64 converts integers, and amounts to base units in decimals; amounts
+// are refused without decimals or when they would lose precision.
+func toInt64(arg interface{}, decimals *int) (int64, bool) {
+	switch value := arg.(type) {
+	case int:
+		return int64(value), true
+	case int32:
+		return int64(value), true
+	case int64:
+		return value, true
+	case json.Number:
+		number, err := value.Int64()
+		return number, err == nil
+	case model.Amount:
+		if decimals == nil {
+			return 0, false
+		}
+		units, err := value.RescaledUnits(*decimals)
+		return units, err == nil
+	}
+	return 0, false
+}
+
+func encodeValue(param ContractParam, arg interface{}, network model.Network) (interface{}, bool) {
+	switch param.Type {
+	case TYPE_STRING:
+		value, ok := arg.(string)
+		return value, ok
+	case TYPE_ADDRESS:
+		value, ok := arg.(string)
+		return value, ok && network.CheckAddress(value)
+	case TYPE_BOOL:
+		value, ok := arg.(bool)
+		return value, ok
+	case TYPE_INT64:
+		return toInt64(arg, param.Decimals)
+	case TYPE_INT64_STRING:
+		if value, ok := arg.(string); ok {
+			_, err := strconv.ParseInt(value, 10, 64)
+			return value, err == nil
+		}
+		value, ok := toInt64(arg, param.Decimals)
+		return strconv.FormatInt(value, 10), ok
+	case TYPE_OBJECT:
+		return arg, true
+	}
+	return nil, false
+}
+
+// DecodeOutput decodes the value a query method returns into its outputs:
+// string for strings and addresses, bool, int64 for int64 and int64string, and
+// the decoded JSON for objects. Without outputs all fields are returned as
+// decoded.
+func (method ContractMethod) DecodeOutput(value string) (map[string]interface{}, exception.SDKResponse) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	err := decoder.Decode(&fields)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.CONTRACT_QUERY_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return nil, SDKRes
+	}
+	if len(method.Outputs) == 0 {
+		return fields, exception.GetSDKRes(exception.SUCCESS)
+	}
+	outputs := make(map[string]interface{}, len(method.Outputs))
+	for _, param := range method.Outputs {
+		output, ok := decodeValue(param.Type, fields[param.Name])
+		if !ok {
+			SDKRes := exception.GetSDKRes(exception.CONTRACT_QUERY_ERROR)
+			SDKRes.ErrorDesc += ": " + method.Name + " returned a wrong " + param.Name
+			return nil, SDKRes
+		}
+		outputs[param.Name] = output
+	}
+	return outputs, exception.GetSDKRes(exception.SUCCESS)
+}
+
+func decodeValue(paramType string, field interface{}) (interface{}, bool) {
+	switch paramType {
+	case TYPE_STRING, TYPE_ADDRESS:
+		value, ok := field.(string)
+		return value, ok
+	case TYPE_BOOL:
+		value, ok := field.(bool)
+		return value, ok
+	case TYPE_INT64, TYPE_INT64_STRING:
+		// contracts are not strict about numbers in strings
+		switch value := field.(type) {
+		case json.Number:
+			number, err := value.Int64()
+			return number, err == nil
+		case string:
+			number, err := strconv.ParseInt(value, 10, 64)
+			return number, err == nil
+		}
+		return nil, false
+	case TYPE_OBJECT:
+		return field, field != nil
+	}
+	return nil, false
+}
+
+// BoundContract invokes the methods of the contract at Address as described
+// by Interface. SourceAddress is the optional sender of queries and the source
+// of the invocation operations.
+type BoundContract struct {
+	Url           string
+	Network       model.Network
+	Address       string
+	SourceAddress string
+	Interface     ContractInterface
+}
+
+// ContractResult is the result of BoundContract.Call: the outputs of a query
+// method, or the operation invoking a mutating method.
+type ContractResult struct {
+	Outputs   map[string]interface{}
+	Operation model.ContractInvokeByBUOperation
+}
+
+// Call runs a query method on the node, or builds the operation of a mutating
+// method, which then goes into a transaction.
+func (bound *BoundContract) Call(name string, args ...interface{}) (ContractResult, exception.SDKResponse) {
+	var result ContractResult
+	method, SDKRes := bound.Interface.Method(name)
+	if SDKRes.ErrorCode != 0 {
+		return result, SDKRes
+	}
+	if method.Query {
+		result.Outputs, SDKRes = bound.Query(name, args...)
+	} else {
+		result.Operation, SDKRes = bound.Invoke(name, args...)
+	}
+	return result, SDKRes
+}
+
+// Query runs a query method on the node and decodes its outputs.
+func (bound *BoundContract) Query(name string, args ...interface{}) (map[string]interface{}, exception.SDKResponse) {
+	method, SDKRes := bound.Interface.Method(name)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	if !method.Query {
+		return nil, argumentError(name + " is not a query method")
+	}
+	input, SDKRes := method.EncodeInput(bound.Network, args...)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	var reqData model.ContractCallRequest
+	reqData.SetContractAddress(bound.Address)
+	reqData.SetSourceAddress(bound.SourceAddress)
+	reqData.SetInput(input)
+	reqData.SetOptType(2)
+	contract := ContractOperation{Url: bound.Url, Network: bound.Network}
+	resData := contract.Call(reqData)
+	if resData.ErrorCode != 0 {
+		return nil, exception.SDKResponse{ErrorCode: resData.ErrorCode, ErrorDesc: resData.ErrorDesc}
+	}
+	if len(resData.Result.QueryRets) == 0 {
+		return nil, exception.GetSDKRes(exception.CONTRACT_QUERY_ERROR)
+	}
+	queryRet := resData.Result.QueryRets[0]
+	if queryRet.Error.Data.Exception != "" {
+		SDKRes := exception.GetSDKRes(exception.CONTRACT_QUERY_ERROR)
+		SDKRes.ErrorDesc += ": " + queryRet.Error.Data.Exception
+		return nil, SDKRes
+	}
+	return method.DecodeOutput(queryRet.Result.Value)
+}
+
+// Invoke builds the operation invoking a mutating method, without BU. Set the
+// amount of the operation to send BU along.
+func (bound *BoundContract) Invoke(name string, args ...interface{}) (model.ContractInvokeByBUOperation, exception.SDKResponse) {
+	var operation model.ContractInvokeByBUOperation
+	method, SDKRes := bound.Interface.Method(name)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	if method.Query {
+		return operation, argumentError(name + " is a query method")
+	}
+	input, SDKRes := method.EncodeInput(bound.Network, args...)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	operation.Init()
+	operation.SetSourceAddress(bound.SourceAddress)
+	operation.SetContractAddress(bound.Address)
+	operation.SetInput(input)
+	return operation, SDKRes
+}
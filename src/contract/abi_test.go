@@ -0,0 +1,175 @@
+// abi_test
+package contract_test
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const (
+	contractAddress = "buQhdBSkJqERBSsYiUShUZFMZQhXvkdNgnYq"
+	ownerAddress    = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+)
+
+const ctp10Interface = `{
+	"name": "CTP10",
+	"methods": [
+		{"name": "balanceOf", "query": true,
+			"inputs": [{"name": "address", "type": "address"}],
+			"outputs": [{"name": "balance", "type": "int64string"}]},
+		{"name": "transfer",
+			"inputs": [{"name": "to", "type": "address"}, {"name": "value", "type": "int64string", "decimals": 4}]}
+	],
+	"events": [
+		{"name": "transfer",
+			"inputs": [{"name": "from", "type": "address"}, {"name": "to", "type": "address"}, {"name": "value", "type": "int64string"}]}
+	]
+}`
+
+func loadCtp10(t *testing.T) contract.ContractInterface {
+	contractInterface, SDKRes := contract.LoadInterface([]byte(ctp10Interface))
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	return contractInterface
+}
+
+func Test_LoadInterface(t *testing.T) {
+	loadCtp10(t)
+	invalid := []string{
+		`{"methods": [{"name": "a"}, {"name": "a"}]}`,
+		`{"methods": [{"name": "a", "inputs": [{"name": "x", "type": "uint256"}]}]}`,
+		`{"methods": [`,
+		`{"methods": [{"name": "a", "inputs": [{"name": "x", "type": "string", "decimals": 2}]}]}`,
+		`{"methods": [{"name": "a", "inputs": [{"name": "x", "type": "int64", "decimals": 19}]}]}`,
+	}
+	for _, data := range invalid {
+		if _, SDKRes := contract.LoadInterface([]byte(data)); SDKRes.ErrorCode != 11074 {
+			t.Errorf("%s is loaded: %d", data, SDKRes.ErrorCode)
+		}
+	}
+}
+
+func Test_EncodeInput(t *testing.T) {
+	method, _ := loadCtp10(t).Method("transfer")
+	input, SDKRes := method.EncodeInput(model.Network{}, ownerAddress, model.NewAmount(1500, 2))
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	// 15.00 is 150000 units with the 4 decimals of the token
+	if input != `{"method":"transfer","params":{"to":"`+ownerAddress+`","value":"150000"}}` {
+		t.Error("input is", input)
+	}
+	if _, SDKRes = method.EncodeInput(model.Network{}, ownerAddress, model.NewAmount(1, 5)); SDKRes.ErrorCode != 11076 {
+		t.Error("amount losing precision is encoded")
+	}
+	plain, _ := loadCtp10(t).Method("transfer")
+	plain.Inputs[1].Decimals = nil
+	if _, SDKRes = plain.EncodeInput(model.Network{}, ownerAddress, model.NewAmount(1500, 2)); SDKRes.ErrorCode != 11076 {
+		t.Error("amount is encoded without decimals")
+	}
+	if _, SDKRes = method.EncodeInput(model.Network{}, "nobody", 1); SDKRes.ErrorCode != 11076 {
+		t.Error("invalid address is encoded")
+	}
+	if _, SDKRes = method.EncodeInput(model.Network{}, ownerAddress); SDKRes.ErrorCode != 11076 {
+		t.Error("missing argument is encoded")
+	}
+}
+
+func Test_BoundContract(t *testing.T) {
+	var request model.CallContractRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &request)
+		w.Write([]byte(`{"error_code":0,"result":{"query_rets":[{"result":{"type":"string","value":"{\"balance\":\"250\"}"}}]}}`))
+	}))
+	defer server.Close()
+	bound := contract.BoundContract{Url: server.URL, Address: contractAddress, Interface: loadCtp10(t)}
+	result, SDKRes := bound.Call("balanceOf", ownerAddress)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if result.Outputs["balance"] != int64(250) {
+		t.Error("balance is", result.Outputs["balance"])
+	}
+	if request.OptType != 2 || request.ContractAddress != contractAddress || request.GasPrice != model.MainnetNetwork().QueryGasPrice {
+		t.Errorf("wrong call %+v", request)
+	}
+	// queries send the fees of the network
+	bound.Network = model.Network{QueryGasPrice: 2000}
+	if _, SDKRes = bound.Call("balanceOf", ownerAddress); SDKRes.ErrorCode != 0 || request.GasPrice != 2000 {
+		t.Errorf("network is not used %+v", request)
+	}
+	result, SDKRes = bound.Call("transfer", ownerAddress, int64(10))
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if result.Operation.GetContractAddress() != contractAddress || result.Operation.Get() != 15 {
+		t.Errorf("wrong operation %+v", result.Operation)
+	}
+	if _, SDKRes = bound.Query("transfer", ownerAddress, int64(10)); SDKRes.ErrorCode != 11076 {
+		t.Error("mutating method is queried")
+	}
+	if _, SDKRes = bound.Call("burn"); SDKRes.ErrorCode != 11075 {
+		t.Error("unknown method is called")
+	}
+}
+
+func Test_GenerateBinding(t *testing.T) {
+	contractInterface := loadCtp10(t)
+	contractInterface.Methods = append(contractInterface.Methods,
+		contract.ContractMethod{Name: "contractInfo", Query: true, Outputs: []contract.ContractParam{{Name: "name", Type: "string"}, {Name: "decimals", Type: "int64"}}},
+		contract.ContractMethod{Name: "set_owner", Inputs: []contract.ContractParam{{Name: "type", Type: "object"}}})
+	source, SDKRes := contract.GenerateBinding(contractInterface, "token", "Ctp10")
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "ctp10.go", source, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the binding must compile against the packages it uses
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err = config.Check("token", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatal(err)
+	}
+	var decls []string
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			decls = append(decls, decl.Name.Name)
+		case *ast.GenDecl:
+			if spec, ok := decl.Specs[0].(*ast.TypeSpec); ok {
+				decls = append(decls, spec.Name.Name)
+			}
+		}
+	}
+	want := "Ctp10 NewCtp10 BalanceOf Transfer Ctp10ContractInfoResult ContractInfo SetOwner"
+	if strings.Join(decls, " ") != want {
+		t.Error("declarations are", decls)
+	}
+	if !strings.Contains(string(source), "func (binding *Ctp10) SetOwner(type_ interface{})") {
+		t.Error("keyword parameter is not renamed")
+	}
+	if !strings.Contains(string(source), "func NewCtp10(url string, network model.Network, address string)") ||
+		!strings.Contains(string(source), "Network: network") {
+		t.Error("constructor does not set the network")
+	}
+	contractInterface.Methods = append(contractInterface.Methods, contract.ContractMethod{Name: "balance_of", Query: true})
+	if _, SDKRes = contract.GenerateBinding(contractInterface, "token", "Ctp10"); SDKRes.ErrorCode != 11074 {
+		t.Error("methods with the same Go name are generated")
+	}
+}
@@ -0,0 +1,234 @@
+// abigen
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/format"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+)
+
+// Go types of the parameter types
+var bindingTypes = map[string]string{
+	TYPE_STRING:       "string",
+	TYPE_ADDRESS:      "string",
+	TYPE_BOOL:         "bool",
+	TYPE_INT64:        "int64",
+	TYPE_INT64_STRING: "int64",
+	TYPE_OBJECT:       "interface{}",
+}
+
+// fields of BoundContract that methods must not collide with
+var bindingFields = map[string]bool{
+	"BoundContract": true, "Url": true, "Address": true, "SourceAddress": true, "Interface": true,
+}
+
+// identifiers of the generated code that parameters must not shadow
+var bindingReserved = map[string]bool{
+	"binding": true, "outputs": true, "SDKRes": true, "result": true,
+	"contract": true, "exception": true, "model": true,
+}
+
+type bindingParam struct {
+	Name    string
+	GoName  string
+	GoType  string
+	Field   string
+	Convert bool
+}
+
+type bindingMethod struct {
+	Name    string
+	GoName  string
+	Query   bool
+	Inputs  []bindingParam
+	Outputs []bindingParam
+}
+
+type bindingData struct {
+	Package   string
+	Type      string
+	Contract  string
+	Interface string
+	Methods   []bindingMethod
+}
+
+const bindingTemplate = `// Code generated by bumo-abigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// {{.Type}}Interface is the interface of the {{.Contract}} contract.
+const {{.Type}}Interface = {{printf "%q" .Interface}}
+
+// {{.Type}} calls the methods of a {{.Contract}} contract.
+type {{.Type}} struct {
+	contract.BoundContract
+}
+
+// New{{.Type}} binds the {{.Contract}} contract at address on network.
+func New{{.Type}}(url string, network model.Network, address string) (*{{.Type}}, exception.SDKResponse) {
+	contractInterface, SDKRes := contract.LoadInterface([]byte({{.Type}}Interface))
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	return &{{.Type}}{contract.BoundContract{Url: url, Network: network, Address: address, Interface: contractInterface}}, SDKRes
+}
+{{range $method := .Methods}}{{if $method.Query}}{{if gt (len $method.Outputs) 1}}
+// {{$.Type}}{{$method.GoName}}Result holds the outputs of {{$method.Name}}.
+type {{$.Type}}{{$method.GoName}}Result struct {
+{{range $method.Outputs}}	{{.Field}} {{.GoType}}
+{{end}}}
+{{end}}
+// {{$method.GoName}} queries {{$method.Name}}.
+func (binding *{{$.Type}}) {{$method.GoName}}({{range $i, $p := $method.Inputs}}{{if $i}}, {{end}}{{$p.GoName}} {{$p.GoType}}{{end}}) ({{if eq (len $method.Outputs) 0}}map[string]interface{}{{else if eq (len $method.Outputs) 1}}{{(index $method.Outputs 0).GoType}}{{else}}{{$.Type}}{{$method.GoName}}Result{{end}}, exception.SDKResponse) {
+	outputs, SDKRes := binding.BoundContract.Query({{printf "%q" $method.Name}}{{range $method.Inputs}}, {{.GoName}}{{end}})
+{{- if eq (len $method.Outputs) 0}}
+	return outputs, SDKRes
+{{- else if eq (len $method.Outputs) 1}}{{$output := index $method.Outputs 0}}
+	if SDKRes.ErrorCode != 0 {
+		var result {{$output.GoType}}
+		return result, SDKRes
+	}
+	return outputs[{{printf "%q" $output.Name}}]{{if $output.Convert}}.({{$output.GoType}}){{end}}, SDKRes
+{{- else}}
+	var result {{$.Type}}{{$method.GoName}}Result
+	if SDKRes.ErrorCode != 0 {
+		return result, SDKRes
+	}
+{{- range $method.Outputs}}
+	result.{{.Field}} = outputs[{{printf "%q" .Name}}]{{if .Convert}}.({{.GoType}}){{end}}
+{{- end}}
+	return result, SDKRes
+{{- end}}
+}
+{{else}}
+// {{$method.GoName}} builds the operation invoking {{$method.Name}}.
+func (binding *{{$.Type}}) {{$method.GoName}}({{range $i, $p := $method.Inputs}}{{if $i}}, {{end}}{{$p.GoName}} {{$p.GoType}}{{end}}) (model.ContractInvokeByBUOperation, exception.SDKResponse) {
+	return binding.BoundContract.Invoke({{printf "%q" $method.Name}}{{range $method.Inputs}}, {{.GoName}}{{end}})
+}
+{{end}}{{end}}`
+
+// GenerateBinding generates the Go source of a binding of a contract interface:
+// a typeName type embedding BoundContract, with one method per contract
+// method. Query methods return their outputs, other methods the invocation
+// operation for BuildBlob.
+func GenerateBinding(contractInterface ContractInterface, packageName string, typeName string) ([]byte, exception.SDKResponse) {
+	SDKRes := contractInterface.Check()
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	if !token.IsIdentifier(packageName) || !token.IsIdentifier(typeName) {
+		return nil, interfaceError("invalid package or type name")
+	}
+	interfaceData, err := json.Marshal(contractInterface)
+	if err != nil {
+		return nil, interfaceError(err.Error())
+	}
+	data := bindingData{
+		Package:   packageName,
+		Type:      typeName,
+		Contract:  contractInterface.Name,
+		Interface: string(interfaceData),
+	}
+	if data.Contract == "" {
+		data.Contract = typeName
+	}
+	goNames := map[string]bool{}
+	for _, method := range contractInterface.Methods {
+		goName := exportedName(method.Name)
+		if goName == "" || goNames[goName] || bindingFields[goName] {
+			return nil, interfaceError("method " + method.Name + " has no distinct Go name")
+		}
+		goNames[goName] = true
+		bound := bindingMethod{Name: method.Name, GoName: goName, Query: method.Query}
+		params := map[string]bool{}
+		for _, input := range method.Inputs {
+			name := paramName(input.Name)
+			for params[name] {
+				name += "_"
+			}
+			params[name] = true
+			bound.Inputs = append(bound.Inputs, bindingParam{Name: input.Name, GoName: name, GoType: bindingTypes[input.Type]})
+		}
+		fields := map[string]bool{}
+		for _, output := range method.Outputs {
+			field := exportedName(output.Name)
+			if field == "" || fields[field] {
+				return nil, interfaceError("output " + output.Name + " of " + method.Name + " has no distinct Go name")
+			}
+			fields[field] = true
+			goType := bindingTypes[output.Type]
+			bound.Outputs = append(bound.Outputs, bindingParam{Name: output.Name, GoType: goType, Field: field, Convert: goType != "interface{}"})
+		}
+		data.Methods = append(data.Methods, bound)
+	}
+	var buf bytes.Buffer
+	err = template.Must(template.New("binding").Parse(bindingTemplate)).Execute(&buf, data)
+	if err != nil {
+		return nil, exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, interfaceError(err.Error())
+	}
+	return source, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// identifier keeps the letters and digits of name, capitalizing the letter
+// after a dropped character: "balance_of" is "balanceOf".
+func identifier(name string) string {
+	var buf bytes.Buffer
+	upper := false
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = buf.Len() > 0
+			continue
+		}
+		if buf.Len() == 0 && unicode.IsDigit(r) {
+			buf.WriteByte('_')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+func exportedName(name string) string {
+	runes := []rune(strings.TrimLeft(identifier(name), "_"))
+	if len(runes) == 0 || !unicode.IsLetter(runes[0]) {
+		return ""
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func paramName(name string) string {
+	name = identifier(name)
+	if name == "" {
+		return "arg_"
+	}
+	if token.IsKeyword(name) || bindingReserved[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// BindingTypeName is the exported Go name of a contract name, "" when it has
+// none.
+func BindingTypeName(contractName string) string {
+	return exportedName(contractName)
+}
@@ -8,24 +8,26 @@ import (
 	"github.com/bumoproject/bumo-sdk-go/src/account"
 	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
 	"github.com/bumoproject/bumo-sdk-go/src/common"
-	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
 	"github.com/bumoproject/bumo-sdk-go/src/exception"
 	"github.com/bumoproject/bumo-sdk-go/src/model"
 )
 
 type ContractOperation struct {
 	Url string
+	// the chain of the node; the zero value is the main network
+	Network model.Network
 }
 
 //Check Valid
 func (contract *ContractOperation) CheckValid(reqData model.ContractCheckValidRequest) model.ContractCheckValidResponse {
 	var Account account.AccountOperation
 	Account.Url = contract.Url
+	Account.Network = contract.Network
 	var reqDataAcc model.AccountGetInfoRequest
 	var resData model.ContractCheckValidResponse
 	resData.Result.IsValid = false
 	reqDataAcc.SetAddress(reqData.GetAddress())
-	if !keypair.CheckAddress(reqData.GetAddress()) {
+	if !contract.Network.CheckAddress(reqData.GetAddress()) {
 		resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -105,14 +107,14 @@ func (contract *ContractOperation) Call(reqData model.ContractCallRequest) model
 		return resData
 	}
 	if reqData.GetSourceAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetSourceAddress()) {
+		if !contract.Network.CheckAddress(reqData.GetSourceAddress()) {
 			resData.ErrorCode = exception.INVALID_SOURCEADDRESS_ERROR
 			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 			return resData
 		}
 	}
 	if reqData.GetContractAddress() != "" {
-		if !keypair.CheckAddress(reqData.GetContractAddress()) {
+		if !contract.Network.CheckAddress(reqData.GetContractAddress()) {
 			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
 			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 			return resData
@@ -133,6 +135,14 @@ func (contract *ContractOperation) Call(reqData model.ContractCallRequest) model
 		OptType:         reqData.GetOptType(),
 		SourceAddress:   reqData.GetSourceAddress(),
 	}
+	// without fees in the request, the query fees of the network are sent
+	network := contract.Network.Resolve()
+	if callData.FeeLimit == 0 {
+		callData.FeeLimit = network.QueryFeeLimit
+	}
+	if callData.GasPrice == 0 {
+		callData.GasPrice = network.QueryGasPrice
+	}
 	reqDataByte, err := json.Marshal(callData)
 	if err != nil {
 		resData.ErrorCode = exception.SYSTEM_ERROR
@@ -174,6 +184,7 @@ func (contract *ContractOperation) GetAddress(reqData model.ContractGetAddressRe
 	var resData model.ContractGetAddressResponse
 	var Transaction blockchain.TransactionOperation
 	Transaction.Url = contract.Url
+	Transaction.Network = contract.Network
 	var reqDataInfo model.TransactionGetInfoRequest
 	reqDataInfo.SetHash(reqData.GetHash())
 	resDataInfo := Transaction.GetInfo(reqDataInfo)
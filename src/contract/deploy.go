@@ -0,0 +1,197 @@
+// deploy
+package contract
+
+import (
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const (
+	defaultDeployTimeout = 60 * time.Second
+	// how often DeployContract asks the node whether the transaction is confirmed
+	deployPollInterval = time.Second
+)
+
+// DeployContract creates a contract account and waits for it: it reads and
+// checks the payload against the size limit of the network, optionally runs
+// init on the node with the init input, evaluates the fee unless both gas
+// price and fee limit are given, signs and submits the transaction, and
+// returns the contract addresses once the transaction is in a ledger, or
+// TRANSACTION_TIMEOUT_ERROR with the hash after the timeout (60 seconds by
+// default).
+func (contract *ContractOperation) DeployContract(reqData model.ContractDeployRequest) model.ContractDeployResponse {
+	var resData model.ContractDeployResponse
+	payload := reqData.GetPayload()
+	if reqData.GetPayloadFile() != "" {
+		data, err := ioutil.ReadFile(reqData.GetPayloadFile())
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.READ_PAYLOAD_FILE_ERROR)
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc + ": " + err.Error()
+			return resData
+		}
+		payload = string(data)
+	}
+	SDKRes := CheckPayload(payload, contract.Network)
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if len(reqData.GetPrivateKeys()) == 0 {
+		SDKRes := exception.GetSDKRes(exception.PRIVATEKEY_NULL_ERROR)
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	if reqData.GetValidateInit() {
+		SDKRes = contract.validateInit(reqData, payload)
+		if SDKRes.ErrorCode != 0 {
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+	}
+	var operation model.ContractCreateOperation
+	operation.Init()
+	operation.SetPayload(payload)
+	operation.SetInitBalance(reqData.GetInitBalance())
+	operation.SetInitInput(reqData.GetInitInput())
+	operation.SetMetadata(reqData.GetMetadata())
+
+	Account := account.AccountOperation{Url: contract.Url, Network: contract.Network}
+	var reqDataNonce model.AccountGetNonceRequest
+	reqDataNonce.SetAddress(reqData.GetSourceAddress())
+	resDataNonce := Account.GetNonce(reqDataNonce)
+	if resDataNonce.ErrorCode != 0 {
+		resData.ErrorCode = resDataNonce.ErrorCode
+		resData.ErrorDesc = resDataNonce.ErrorDesc
+		return resData
+	}
+	nonce := resDataNonce.Result.Nonce + 1
+
+	transaction := blockchain.TransactionOperation{Url: contract.Url, Network: contract.Network}
+	gasPrice, feeLimit := reqData.GetGasPrice(), reqData.GetFeeLimit()
+	if gasPrice == 0 || feeLimit == 0 {
+		var reqDataFee model.TransactionEvaluateFeeRequest
+		reqDataFee.SetSourceAddress(reqData.GetSourceAddress())
+		reqDataFee.SetNonce(nonce)
+		reqDataFee.SetSignatureNumber(strconv.Itoa(len(reqData.GetPrivateKeys())))
+		reqDataFee.SetMetadata(reqData.GetMetadata())
+		reqDataFee.SetOperation(operation)
+		resDataFee := transaction.EvaluateFee(reqDataFee)
+		if resDataFee.ErrorCode != 0 {
+			resData.ErrorCode = resDataFee.ErrorCode
+			resData.ErrorDesc = resDataFee.ErrorDesc
+			return resData
+		}
+		if gasPrice == 0 {
+			gasPrice = resDataFee.Result.GasPrice
+		}
+		if feeLimit == 0 {
+			feeLimit = resDataFee.Result.FeeLimit
+		}
+	}
+	resData.Result.GasPrice = gasPrice
+	resData.Result.FeeLimit = feeLimit
+
+	var reqDataBlob model.TransactionBuildBlobRequest
+	reqDataBlob.SetSourceAddress(reqData.GetSourceAddress())
+	reqDataBlob.SetNonce(nonce)
+	reqDataBlob.SetGasPrice(gasPrice)
+	reqDataBlob.SetFeeLimit(feeLimit)
+	reqDataBlob.SetMetadata(reqData.GetMetadata())
+	reqDataBlob.SetOperation(operation)
+	resDataBlob := transaction.BuildBlob(reqDataBlob)
+	if resDataBlob.ErrorCode != 0 {
+		resData.ErrorCode = resDataBlob.ErrorCode
+		resData.ErrorDesc = resDataBlob.ErrorDesc
+		return resData
+	}
+	var reqDataSign model.TransactionSignRequest
+	reqDataSign.SetBlob(resDataBlob.Result.Blob)
+	reqDataSign.SetPrivateKeys(reqData.GetPrivateKeys())
+	resDataSign := transaction.Sign(reqDataSign)
+	if resDataSign.ErrorCode != 0 {
+		resData.ErrorCode = resDataSign.ErrorCode
+		resData.ErrorDesc = resDataSign.ErrorDesc
+		return resData
+	}
+	var reqDataSubmit model.TransactionSubmitRequest
+	reqDataSubmit.SetBlob(resDataBlob.Result.Blob)
+	reqDataSubmit.SetSignatures(resDataSign.Result.Signatures)
+	resDataSubmit := transaction.Submit(reqDataSubmit)
+	if resDataSubmit.ErrorCode != 0 {
+		resData.ErrorCode = resDataSubmit.ErrorCode
+		resData.ErrorDesc = resDataSubmit.ErrorDesc
+		return resData
+	}
+	resData.Result.Hash = resDataSubmit.Result.Hash
+
+	SDKRes = contract.waitTransaction(resData.Result.Hash, reqData.GetTimeout())
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	var reqDataAddress model.ContractGetAddressRequest
+	reqDataAddress.SetHash(resData.Result.Hash)
+	resDataAddress := contract.GetAddress(reqDataAddress)
+	if resDataAddress.ErrorCode != 0 {
+		resData.ErrorCode = resDataAddress.ErrorCode
+		resData.ErrorDesc = resDataAddress.ErrorDesc
+		return resData
+	}
+	resData.Result.ContractAddresInfos = resDataAddress.Result.ContractAddresInfos
+	return resData
+}
+
+// validateInit runs the init function of the payload with the init input.
+func (contract *ContractOperation) validateInit(reqData model.ContractDeployRequest, payload string) exception.SDKResponse {
+	var reqDataCall model.ContractCallRequest
+	reqDataCall.SetSourceAddress(reqData.GetSourceAddress())
+	reqDataCall.SetCode(payload)
+	reqDataCall.SetInput(reqData.GetInitInput())
+	reqDataCall.SetOptType(0)
+	resDataCall := contract.Call(reqDataCall)
+	if resDataCall.ErrorCode == exception.CONNECTNETWORK_ERROR || resDataCall.ErrorCode == exception.SYSTEM_ERROR {
+		return exception.SDKResponse{ErrorCode: resDataCall.ErrorCode, ErrorDesc: resDataCall.ErrorDesc}
+	}
+	if resDataCall.ErrorCode != 0 {
+		SDKRes := exception.GetSDKRes(exception.INVALID_INITINPUT_ERROR)
+		SDKRes.ErrorDesc += ": " + resDataCall.ErrorDesc
+		return SDKRes
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+// waitTransaction polls the node until the transaction is in a ledger. The
+// node reports a transaction it has not applied yet as not found.
+func (contract *ContractOperation) waitTransaction(hash string, timeout time.Duration) exception.SDKResponse {
+	if timeout <= 0 {
+		timeout = defaultDeployTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	transaction := blockchain.TransactionOperation{Url: contract.Url, Network: contract.Network}
+	var reqDataInfo model.TransactionGetInfoRequest
+	reqDataInfo.SetHash(hash)
+	for {
+		resDataInfo := transaction.GetInfo(reqDataInfo)
+		if resDataInfo.ErrorCode == 0 {
+			return exception.GetSDKRes(exception.SUCCESS)
+		}
+		if resDataInfo.ErrorCode != 4 {
+			return exception.SDKResponse{ErrorCode: resDataInfo.ErrorCode, ErrorDesc: resDataInfo.ErrorDesc}
+		}
+		if time.Now().Add(deployPollInterval).After(deadline) {
+			return exception.GetSDKRes(exception.TRANSACTION_TIMEOUT_ERROR)
+		}
+		time.Sleep(deployPollInterval)
+	}
+}
@@ -0,0 +1,117 @@
+// deploy_test
+package contract_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const deployHash = "4c2bb3bf1c3cd1bb4c0ba0e2d62fc2c9ddaea65fdbbe88e8a9d4eaa1a7e7a52d"
+
+func Test_CheckPayload(t *testing.T) {
+	if SDKRes := contract.CheckPayload(model.Payload, model.Network{}); SDKRes.ErrorCode != 0 {
+		t.Error("CTP10 payload:", SDKRes.ErrorDesc)
+	}
+	valid := "'use strict';\n// init }\nfunction init(input) { let re = /[}]\\//g; let s = `a${input}b}`; /* ) */ }\nfunction main(input) { return 1 / 2; }"
+	if SDKRes := contract.CheckPayload(valid, model.Network{}); SDKRes.ErrorCode != 0 {
+		t.Error(SDKRes.ErrorDesc)
+	}
+	invalid := map[string]string{
+		"function init(){}\nfunction main(){":      "unclosed bracket at line 2",
+		"function init(){}\nfunction main(){)}":    "unexpected ) at line 2",
+		"function init(){ let s = 'abc\n}":         "unterminated string at line 1",
+		"function init(){}\n/* function main(){}":  "unterminated comment at line 2",
+		"function init(){ let s = `${1}; }":        "unterminated template literal at line 1",
+		"function main(){}":                        "function init is missing",
+		"function init(){ return /abc; }\nmain();": "unterminated regular expression at line 1",
+	}
+	for payload, desc := range invalid {
+		SDKRes := contract.CheckPayload(payload, model.Network{})
+		if SDKRes.ErrorCode != 11079 || SDKRes.ErrorDesc != "The contract payload has a syntax error: "+desc {
+			t.Errorf("%q: %s", payload, SDKRes.ErrorDesc)
+		}
+	}
+	if SDKRes := contract.CheckPayload(string(make([]byte, model.MainnetNetwork().MaxPayloadSize+1)), model.Network{}); SDKRes.ErrorCode != 11078 {
+		t.Error("large payload is accepted")
+	}
+	if SDKRes := contract.CheckPayload(valid, model.Network{MaxPayloadSize: len(valid) - 1}); SDKRes.ErrorCode != 11078 {
+		t.Error("payload above the limit of the network is accepted")
+	}
+}
+
+// a node that has the deployment in a ledger as soon as it is submitted
+func deployNode(submitted *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/getAccount":
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + ownerAddress + `","nonce":7}}`))
+		case "/submitTransaction":
+			*submitted = true
+			w.Write([]byte(`{"results":[{"error_code":0,"hash":"` + deployHash + `"}],"success_count":1}`))
+		case "/getTransactionHistory":
+			w.Write([]byte(`{"error_code":0,"result":{"total_count":1,"transactions":[{"error_code":0,
+				"error_desc":"[{\"contract_address\":\"` + contractAddress + `\",\"operation_index\":0}]",
+				"hash":"` + deployHash + `","ledger_seq":9,"transaction":{"metadata":"","operations":[]}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_DeployContract(t *testing.T) {
+	_, privateKey, address, err := keypair.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "contract.js")
+	ioutil.WriteFile(file, []byte(model.Payload), 0644)
+
+	var submitted bool
+	server := deployNode(&submitted)
+	defer server.Close()
+	operation := contract.ContractOperation{Url: server.URL}
+	var reqData model.ContractDeployRequest
+	reqData.SetSourceAddress(address)
+	reqData.SetPrivateKeys([]string{privateKey})
+	reqData.SetPayloadFile(file)
+	reqData.SetInitBalance(10000000)
+	reqData.SetInitInput(`{"params":{"name":"Token","symbol":"TKN","decimals":2,"supply":"1000"}}`)
+	reqData.SetGasPrice(1000)
+	reqData.SetFeeLimit(1000000000)
+	resData := operation.DeployContract(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	if !submitted || resData.Result.Hash != deployHash {
+		t.Errorf("wrong result %+v", resData.Result)
+	}
+	infos := resData.Result.ContractAddresInfos
+	if len(infos) != 1 || infos[0].ContractAddres != contractAddress {
+		t.Errorf("wrong addresses %+v", infos)
+	}
+
+	// a broken payload is not submitted
+	submitted = false
+	reqData.SetPayloadFile("")
+	reqData.SetPayload("function init(){}")
+	if resData = operation.DeployContract(reqData); resData.ErrorCode != 11079 || submitted {
+		t.Error("broken payload is deployed:", resData.ErrorDesc)
+	}
+	reqData.SetPayloadFile(filepath.Join(dir, "missing.js"))
+	if resData = operation.DeployContract(reqData); resData.ErrorCode != 11080 {
+		t.Error("missing file is deployed:", resData.ErrorDesc)
+	}
+}
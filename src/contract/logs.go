@@ -0,0 +1,191 @@
+// logs
+package contract
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// DecodeDatas decodes the datas of a log with the topic of the event into its
+// inputs, in order: string for strings and addresses, bool, int64 for int64
+// and int64string, and the decoded JSON for objects.
+func (event ContractEvent) DecodeDatas(datas []string) (map[string]interface{}, exception.SDKResponse) {
+	if len(datas) != len(event.Inputs) {
+		return nil, argumentError(event.Name + " has " + strconv.Itoa(len(event.Inputs)) + " datas, the log has " + strconv.Itoa(len(datas)))
+	}
+	fields := make(map[string]interface{}, len(datas))
+	for i, param := range event.Inputs {
+		value, ok := decodeData(param.Type, datas[i])
+		if !ok {
+			return nil, argumentError(event.Name + "." + param.Name + " is not " + param.Type)
+		}
+		fields[param.Name] = value
+	}
+	return fields, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// decodeData converts a log data, which tlog always writes as a string.
+func decodeData(paramType string, data string) (interface{}, bool) {
+	switch paramType {
+	case TYPE_STRING:
+		return data, true
+	case TYPE_ADDRESS:
+		return data, data != ""
+	case TYPE_BOOL:
+		value, err := strconv.ParseBool(data)
+		return value, err == nil
+	case TYPE_INT64, TYPE_INT64_STRING:
+		value, err := strconv.ParseInt(data, 10, 64)
+		return value, err == nil
+	case TYPE_OBJECT:
+		var value interface{}
+		decoder := json.NewDecoder(strings.NewReader(data))
+		decoder.UseNumber()
+		return value, decoder.Decode(&value) == nil
+	}
+	return nil, false
+}
+
+// LogFilter selects logs by the address that wrote them and by topic. An empty
+// list matches everything. With an interface, the logs whose topic is one of
+// its events get their datas decoded into Fields.
+type LogFilter struct {
+	Addresses []string
+	Topics    []string
+	Interface *ContractInterface
+}
+
+// Match reports whether the filter selects the log.
+func (filter LogFilter) Match(log model.ContractLog) bool {
+	return matchAny(filter.Addresses, log.Address) && matchAny(filter.Topics, log.Topic)
+}
+
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the logs of one ledger the filter selects, decoded.
+func (filter LogFilter) Filter(header model.GetInfoHeader, transactions []model.Transactioninfo) []model.ContractLog {
+	var logs []model.ContractLog
+	for _, log := range ExtractLogs(header, transactions) {
+		if !filter.Match(log) {
+			continue
+		}
+		if filter.Interface != nil {
+			if event, ok := filter.Interface.Event(log.Topic); ok {
+				fields, SDKRes := event.DecodeDatas(log.Datas)
+				if SDKRes.ErrorCode == 0 {
+					log.Fields = fields
+				}
+			}
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+// ExtractLogs returns the logs of the successful transactions of one ledger,
+// including the transactions triggered by contracts as LedgerScanner returns
+// them. The address of a log is the source of its operation, which for a tlog
+// is the contract. The index numbers the logs of the ledger in order, so it is
+// stable across scans of the same ledger.
+func ExtractLogs(header model.GetInfoHeader, transactions []model.Transactioninfo) []model.ContractLog {
+	var logs []model.ContractLog
+	var index int64
+	for _, transaction := range transactions {
+		if transaction.ErrorCode != 0 {
+			continue
+		}
+		for _, operation := range transaction.Transaction.Operations {
+			// written by LogCreateOperation or by tlog in a contract
+			if operation.Type != int64(protocol.Operation_LOG) {
+				continue
+			}
+			address := operation.SourceAddress
+			if address == "" {
+				address = transaction.Transaction.SourceAddress
+			}
+			logs = append(logs, model.ContractLog{
+				Address:   address,
+				Topic:     operation.Log.Topic,
+				Datas:     operation.Log.Datas,
+				Hash:      transaction.Hash,
+				Index:     index,
+				LedgerSeq: header.Number,
+				CloseTime: header.CloseTime,
+			})
+			index++
+		}
+	}
+	return logs
+}
+
+// LogHandler receives one log selected by a filter. Returning an error stops
+// the scan before the ledger of the log is counted as scanned, so some logs of
+// that ledger may be delivered again when the scan is resumed.
+type LogHandler func(log model.ContractLog) error
+
+// LogWatcher delivers the logs a filter selects to a handler, ledger by
+// ledger. Its Apply method is a blockchain.LedgerHandler.
+type LogWatcher struct {
+	Url     string
+	Network model.Network
+	Filter  LogFilter
+	Handler LogHandler
+}
+
+// Apply
+func (watcher *LogWatcher) Apply(header model.GetInfoHeader, transactions []model.Transactioninfo) error {
+	for _, log := range watcher.Filter.Filter(header, transactions) {
+		err := watcher.Handler(log)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Scan delivers the logs of the ledgers from start to end, both included. An
+// end of 0 scans up to the latest ledger. The returned sequence is the next
+// ledger to scan.
+func (watcher *LogWatcher) Scan(start int64, end int64) (int64, exception.SDKResponse) {
+	scanner := blockchain.LedgerScanner{Url: watcher.Url, Network: watcher.Network}
+	return scanner.Scan(start, end, watcher.Apply)
+}
+
+// Watch delivers the logs from start on as ledgers close, asking the node for
+// new ledgers every interval, until stop is closed or a scan fails. It returns
+// the next ledger to scan, so a watch can be resumed from it.
+func (watcher *LogWatcher) Watch(start int64, interval time.Duration, stop <-chan struct{}) (int64, exception.SDKResponse) {
+	next := start
+	for {
+		scanned, SDKRes := watcher.Scan(next, 0)
+		if SDKRes.ErrorCode != 0 {
+			return scanned, SDKRes
+		}
+		// a start past the latest ledger is kept until the ledger closes
+		if scanned > next {
+			next = scanned
+		}
+		select {
+		case <-stop:
+			return next, exception.GetSDKRes(exception.SUCCESS)
+		case <-time.After(interval):
+		}
+	}
+}
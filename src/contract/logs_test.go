@@ -0,0 +1,99 @@
+// logs_test
+package contract_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const triggeredHash = "9b2f6a0c37e4d5b8a1c0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8"
+
+// a node with two ledgers: the first is empty, the second has a log written by
+// the owner and a transfer log written by a contract it invokes
+func logNode() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/getLedger":
+			seq := query.Get("seq")
+			if seq == "" {
+				seq = "2"
+			}
+			w.Write([]byte(`{"error_code":0,"result":{"header":{"seq":` + seq + `,"close_time":1000}}}`))
+		case query.Get("ledger_seq") == "1":
+			w.Write([]byte(`{"error_code":4}`))
+		case query.Get("ledger_seq") == "2":
+			w.Write([]byte(`{"error_code":0,"result":{"total_count":2,"transactions":[
+				{"error_code":0,"hash":"h1","transaction":{"source_address":"` + ownerAddress + `","operations":[
+					{"type":8,"log":{"topic":"note","datas":["hello"]}}]}},
+				{"error_code":0,"hash":"h2","contract_tx_hashes":["` + triggeredHash + `"],"transaction":{"source_address":"` + ownerAddress + `","operations":[
+					{"type":7,"pay_coin":{"dest_address":"` + contractAddress + `"}}]}}]}}`))
+		case query.Get("hash") == triggeredHash:
+			w.Write([]byte(`{"error_code":0,"result":{"total_count":1,"transactions":[
+				{"error_code":0,"hash":"` + triggeredHash + `","transaction":{"source_address":"` + contractAddress + `","operations":[
+					{"type":8,"log":{"topic":"transfer","datas":["` + ownerAddress + `","` + contractAddress + `","250"]}}]}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_LogWatcher(t *testing.T) {
+	server := logNode()
+	defer server.Close()
+	contractInterface := loadCtp10(t)
+	var logs []model.ContractLog
+	watcher := contract.LogWatcher{Url: server.URL, Handler: func(log model.ContractLog) error {
+		logs = append(logs, log)
+		return nil
+	}}
+
+	// without a filter every log is delivered, undecoded
+	next, SDKRes := watcher.Scan(1, 0)
+	if SDKRes.ErrorCode != 0 || next != 3 {
+		t.Fatal(next, SDKRes.ErrorDesc)
+	}
+	if len(logs) != 2 || logs[0].Address != ownerAddress || logs[1].Address != contractAddress || logs[1].Index != 1 || logs[1].Hash != triggeredHash {
+		t.Fatalf("wrong logs %+v", logs)
+	}
+	if logs[1].Fields != nil || logs[1].LedgerSeq != 2 || logs[1].CloseTime != 1000 {
+		t.Errorf("wrong log %+v", logs[1])
+	}
+
+	// the filter selects the contract and decodes its events
+	logs = nil
+	watcher.Filter = contract.LogFilter{Addresses: []string{contractAddress}, Interface: &contractInterface}
+	stop := make(chan struct{})
+	close(stop)
+	if next, SDKRes = watcher.Watch(2, time.Millisecond, stop); SDKRes.ErrorCode != 0 || next != 3 {
+		t.Fatal(next, SDKRes.ErrorDesc)
+	}
+	if len(logs) != 1 || logs[0].Topic != "transfer" {
+		t.Fatalf("wrong logs %+v", logs)
+	}
+	fields := logs[0].Fields
+	if fields["from"] != ownerAddress || fields["to"] != contractAddress || fields["value"] != int64(250) {
+		t.Errorf("wrong fields %v", fields)
+	}
+}
+
+func Test_DecodeDatas(t *testing.T) {
+	event := contract.ContractEvent{Name: "e", Inputs: []contract.ContractParam{
+		{Name: "ok", Type: contract.TYPE_BOOL}, {Name: "info", Type: contract.TYPE_OBJECT},
+	}}
+	fields, SDKRes := event.DecodeDatas([]string{"true", `{"a":1}`})
+	if SDKRes.ErrorCode != 0 || fields["ok"] != true || fields["info"].(map[string]interface{})["a"] == nil {
+		t.Error(fields, SDKRes.ErrorDesc)
+	}
+	if _, SDKRes = event.DecodeDatas([]string{"yes", "{}"}); SDKRes.ErrorCode != 11076 {
+		t.Error("bad bool is decoded")
+	}
+	if _, SDKRes = event.DecodeDatas([]string{"true"}); SDKRes.ErrorCode != 11076 {
+		t.Error("missing data is decoded")
+	}
+}
@@ -0,0 +1,192 @@
+// payload
+package contract
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+var (
+	initFunction = regexp.MustCompile(`\bfunction\s+init\s*\(`)
+	mainFunction = regexp.MustCompile(`\bfunction\s+main\s*\(`)
+)
+
+// keywords after which a slash starts a regular expression
+var regexpKeywords = map[string]bool{
+	"return": true, "typeof": true, "case": true, "do": true, "else": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "instanceof": true, "yield": true,
+}
+
+// CheckPayload checks a contract payload before it is deployed: its size,
+// against the MaxPayloadSize of network, the init and main functions the node
+// calls, and that its brackets, strings, template literals, regular
+// expressions and comments are closed. It is a lexical check; the node still
+// parses the payload when it is deployed.
+func CheckPayload(payload string, network model.Network) exception.SDKResponse {
+	if payload == "" {
+		return exception.GetSDKRes(exception.INVALID_PAYLOAD_ERROR)
+	}
+	if len(payload) > network.Resolve().MaxPayloadSize {
+		return exception.GetSDKRes(exception.INVALID_PAYLOAD_SIZE_ERROR)
+	}
+	desc := scanPayload(payload)
+	if desc == "" && !initFunction.MatchString(payload) {
+		desc = "function init is missing"
+	}
+	if desc == "" && !mainFunction.MatchString(payload) {
+		desc = "function main is missing"
+	}
+	if desc != "" {
+		SDKRes := exception.GetSDKRes(exception.CONTRACT_SYNTAX_ERROR)
+		SDKRes.ErrorDesc += ": " + desc
+		return SDKRes
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// scanPayload returns what is wrong with the tokens of payload, "" if nothing.
+func scanPayload(payload string) string {
+	line := 1
+	at := func(desc string) string {
+		return desc + " at line " + strconv.Itoa(line)
+	}
+	// closers of the open brackets; 'T' is the brace closing ${ in a template
+	var stack []byte
+	regexpAllowed := true
+	i := 0
+	// scanTemplate scans template text up to the closing backtick or ${
+	scanTemplate := func() string {
+		for ; i < len(payload); i++ {
+			switch payload[i] {
+			case '\\':
+				i++
+			case '\n':
+				line++
+			case '`':
+				i++
+				regexpAllowed = false
+				return ""
+			case '$':
+				if i+1 < len(payload) && payload[i+1] == '{' {
+					i += 2
+					stack = append(stack, 'T')
+					regexpAllowed = true
+					return ""
+				}
+			}
+		}
+		return at("unterminated template literal")
+	}
+	for i < len(payload) {
+		c := payload[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '/' && i+1 < len(payload) && payload[i+1] == '/':
+			for i < len(payload) && payload[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(payload) && payload[i+1] == '*':
+			start := line
+			i += 2
+			for i < len(payload) && !(payload[i] == '*' && i+1 < len(payload) && payload[i+1] == '/') {
+				if payload[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			if i >= len(payload) {
+				line = start
+				return at("unterminated comment")
+			}
+			i += 2
+		case c == '\'' || c == '"':
+			i++
+			for i < len(payload) && payload[i] != c {
+				if payload[i] == '\n' {
+					return at("unterminated string")
+				}
+				if payload[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(payload) {
+				return at("unterminated string")
+			}
+			i++
+			regexpAllowed = false
+		case c == '`':
+			i++
+			if desc := scanTemplate(); desc != "" {
+				return desc
+			}
+		case c == '/' && regexpAllowed:
+			i++
+			inClass := false
+			for i < len(payload) && (payload[i] != '/' || inClass) {
+				switch payload[i] {
+				case '\n':
+					return at("unterminated regular expression")
+				case '\\':
+					i++
+				case '[':
+					inClass = true
+				case ']':
+					inClass = false
+				}
+				i++
+			}
+			if i >= len(payload) {
+				return at("unterminated regular expression")
+			}
+			i++
+			regexpAllowed = false
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, map[byte]byte{'(': ')', '[': ']', '{': '}'}[c])
+			i++
+			regexpAllowed = true
+		case c == ')' || c == ']' || c == '}':
+			if len(stack) == 0 {
+				return at("unexpected " + string(c))
+			}
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			i++
+			if open == 'T' && c == '}' {
+				if desc := scanTemplate(); desc != "" {
+					return desc
+				}
+				continue
+			}
+			if open != c {
+				return at("unexpected " + string(c))
+			}
+			regexpAllowed = c == '}'
+		case isWordByte(c):
+			start := i
+			for i < len(payload) && isWordByte(payload[i]) {
+				i++
+			}
+			regexpAllowed = regexpKeywords[payload[start:i]]
+		default:
+			// operators and other punctuation
+			i++
+			regexpAllowed = true
+		}
+	}
+	if len(stack) != 0 {
+		return at("unclosed bracket")
+	}
+	return ""
+}
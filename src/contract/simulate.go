@@ -0,0 +1,143 @@
+// simulate
+package contract
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// simulationWrapper runs a contract payload with its storage, balances and
+// ledger context taken from the overrides. The payload becomes the body of a
+// function whose parameters shadow the builtins of the node, so the builtins
+// themselves are left untouched; init, main and query are forwarded to the
+// functions the payload declares.
+const simulationWrapper = `'use strict';
+const sdkSimulation = (function (state, balances) {
+return (function (storageLoad, storageStore, storageDel, getBalance, blockNumber, blockTimestamp, thisAddress) {
+%PAYLOAD%
+;return {
+init: typeof init === 'function' ? init : undefined,
+main: typeof main === 'function' ? main : undefined,
+query: typeof query === 'function' ? query : undefined
+};
+})(
+function (key) { return Object.prototype.hasOwnProperty.call(state, key) ? state[key] : false; },
+function (key, value) { state[key] = value; return storageStore(key, value); },
+function (key) { delete state[key]; return storageDel(key); },
+function (address) { return Object.prototype.hasOwnProperty.call(balances, address) ? balances[address] : getBalance(address); },
+%BLOCKNUMBER%, %BLOCKTIMESTAMP%, %THISADDRESS%);
+})(%STATE%, %BALANCES%);
+function init(input) { return sdkSimulation.init(input); }
+function main(input) { return sdkSimulation.main(input); }
+function query(input) { return sdkSimulation.query(input); }
+`
+
+// Simulate executes a contract on the node like Call, with state overrides.
+// The contract balance is passed to the node as is. The source balance,
+// metadata and block number and timestamp are not accepted by the node, so
+// when any of them is set the contract is run as code inside a wrapper that
+// answers getBalance, storageLoad, blockNumber and blockTimestamp from the
+// overrides; the storage is the metadata of the contract account with the
+// overridden keys replaced. Nothing is written to the chain either way.
+func (contract *ContractOperation) Simulate(reqData model.ContractSimulateRequest) model.ContractSimulateResponse {
+	var resData model.ContractSimulateResponse
+	reqDataCall := reqData.GetCall()
+	if reqDataCall.GetContractAddress() == "" && reqDataCall.GetCode() == "" {
+		resData.ErrorCode = exception.CONTRACTADDRESS_CODE_BOTH_NULL_ERROR
+		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
+		return resData
+	}
+	sourceBalance, hasSourceBalance := reqData.GetSourceBalance()
+	overridden := hasSourceBalance || reqData.GetMetadatas() != nil || reqData.GetBlockNumber() != 0 || reqData.GetBlockTimestamp() != 0
+	if overridden {
+		code, SDKRes := contract.simulationCode(reqDataCall, reqData, sourceBalance, hasSourceBalance)
+		if SDKRes.ErrorCode != 0 {
+			resData.ErrorCode = SDKRes.ErrorCode
+			resData.ErrorDesc = SDKRes.ErrorDesc
+			return resData
+		}
+		reqDataCall.SetCode(code)
+		reqDataCall.SetContractAddress("")
+	}
+	resDataCall := contract.Call(reqDataCall)
+	resData.ErrorCode = resDataCall.ErrorCode
+	resData.ErrorDesc = resDataCall.ErrorDesc
+	resData.Result.Logs = resDataCall.Result.Logs
+	resData.Result.QueryRets = resDataCall.Result.QueryRets
+	resData.Result.Stat = resDataCall.Result.Stat
+	resData.Result.Txs = resDataCall.Result.Txs
+	resData.Result.Overridden = overridden
+	for _, queryRet := range resDataCall.Result.QueryRets {
+		if queryRet.Error.Data.Exception != "" {
+			resData.Result.Exceptions = append(resData.Result.Exceptions, queryRet.Error.Data.Exception)
+		}
+	}
+	return resData
+}
+
+func (contract *ContractOperation) simulationCode(reqDataCall model.ContractCallRequest, reqData model.ContractSimulateRequest, sourceBalance int64, hasSourceBalance bool) (string, exception.SDKResponse) {
+	payload := reqDataCall.GetCode()
+	state := make(map[string]string)
+	thisAddress := "thisAddress"
+	if reqDataCall.GetContractAddress() != "" {
+		var reqDataInfo model.ContractGetInfoRequest
+		reqDataInfo.SetAddress(reqDataCall.GetContractAddress())
+		resDataInfo := contract.GetInfo(reqDataInfo)
+		if resDataInfo.ErrorCode != 0 {
+			return "", exception.SDKResponse{ErrorCode: resDataInfo.ErrorCode, ErrorDesc: resDataInfo.ErrorDesc}
+		}
+		payload = resDataInfo.Result.Contract.Payload
+		Account := account.AccountOperation{Url: contract.Url, Network: contract.Network}
+		var reqDataMetadata model.AccountGetMetadataRequest
+		reqDataMetadata.SetAddress(reqDataCall.GetContractAddress())
+		resDataMetadata := Account.GetMetadata(reqDataMetadata)
+		if resDataMetadata.ErrorCode != 0 && resDataMetadata.ErrorCode != exception.NO_METADATA_ERROR {
+			return "", exception.SDKResponse{ErrorCode: resDataMetadata.ErrorCode, ErrorDesc: resDataMetadata.ErrorDesc}
+		}
+		for _, metadata := range resDataMetadata.Result.Metadatas {
+			state[metadata.Key] = metadata.Value
+		}
+		thisAddress = strconv.Quote(reqDataCall.GetContractAddress())
+	}
+	for key, value := range reqData.GetMetadatas() {
+		state[key] = value
+	}
+	balances := make(map[string]string)
+	if hasSourceBalance {
+		if reqDataCall.GetSourceAddress() == "" {
+			return "", exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
+		}
+		balances[reqDataCall.GetSourceAddress()] = strconv.FormatInt(sourceBalance, 10)
+	}
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return "", exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	balancesData, err := json.Marshal(balances)
+	if err != nil {
+		return "", exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	blockNumber := "blockNumber"
+	if reqData.GetBlockNumber() != 0 {
+		blockNumber = strconv.FormatInt(reqData.GetBlockNumber(), 10)
+	}
+	blockTimestamp := "blockTimestamp"
+	if reqData.GetBlockTimestamp() != 0 {
+		blockTimestamp = strconv.FormatInt(reqData.GetBlockTimestamp(), 10)
+	}
+	replacer := strings.NewReplacer(
+		"%BLOCKNUMBER%", blockNumber,
+		"%BLOCKTIMESTAMP%", blockTimestamp,
+		"%THISADDRESS%", thisAddress,
+		"%STATE%", string(stateData),
+		"%BALANCES%", string(balancesData),
+	)
+	// the payload goes in last so that its text is never replaced
+	code := replacer.Replace(simulationWrapper)
+	return strings.Replace(code, "%PAYLOAD%", payload, 1), exception.GetSDKRes(exception.SUCCESS)
+}
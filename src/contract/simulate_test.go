@@ -0,0 +1,81 @@
+// simulate_test
+package contract_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// a node serving one contract account with one metadata
+func simulationNode(calls *[]model.CallContractRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/getAccount" {
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + contractAddress + `",
+				"priv":{"master_weight":0,"thresholds":{"tx_threshold":1}},
+				"contract":{"payload":"'use strict';function main(input){}function query(input){return storageLoad('owner');}"},
+				"metadatas":[{"key":"owner","value":"alice","version":1},{"key":"supply","value":"100","version":1}]}}`))
+			return
+		}
+		var call model.CallContractRequest
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &call)
+		*calls = append(*calls, call)
+		w.Write([]byte(`{"error_code":0,"result":{"stat":{"step":12},"query_rets":[{"error":{"data":{"exception":"boom"}}}]}}`))
+	}))
+}
+
+func Test_Simulate(t *testing.T) {
+	var calls []model.CallContractRequest
+	server := simulationNode(&calls)
+	defer server.Close()
+	operation := contract.ContractOperation{Url: server.URL}
+	var reqDataCall model.ContractCallRequest
+	reqDataCall.SetContractAddress(contractAddress)
+	reqDataCall.SetSourceAddress(ownerAddress)
+	reqDataCall.SetOptType(2)
+	reqDataCall.SetContractBalance("500")
+	var reqData model.ContractSimulateRequest
+	reqData.SetCall(reqDataCall)
+
+	// without overrides the call goes to the node unchanged
+	resData := operation.Simulate(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	if calls[0].ContractAddress != contractAddress || calls[0].Code != "" || calls[0].ContractBalance != "500" {
+		t.Errorf("wrong call %+v", calls[0])
+	}
+	if resData.Result.Overridden || resData.Result.Stat.Step != 12 || len(resData.Result.Exceptions) != 1 || resData.Result.Exceptions[0] != "boom" {
+		t.Errorf("wrong result %+v", resData.Result)
+	}
+
+	// with overrides the payload runs as code with the merged storage
+	reqData.SetMetadata("owner", "bob")
+	reqData.SetSourceBalance(42)
+	reqData.SetBlockNumber(1000)
+	resData = operation.Simulate(reqData)
+	if resData.ErrorCode != 0 {
+		t.Fatal(resData.ErrorDesc)
+	}
+	code := calls[1].Code
+	if calls[1].ContractAddress != "" || !resData.Result.Overridden {
+		t.Errorf("wrong call %+v", calls[1])
+	}
+	for _, part := range []string{
+		"function query(input){return storageLoad('owner');}",
+		`{"owner":"bob","supply":"100"}`,
+		`{"` + ownerAddress + `":"42"}`,
+		`1000, blockTimestamp, "` + contractAddress + `"`,
+	} {
+		if !strings.Contains(code, part) {
+			t.Errorf("code does not contain %s", part)
+		}
+	}
+}
@@ -24,6 +24,9 @@ const (
 	DeAddressSize    = 20
 )
 
+//The leading bytes of a BUMO address, which make the encoded address start with bu
+var AddressPrefix = []byte{0X01, 0X56}
+
 //Create
 func Create() (publicKey string, privateKey string, address string, err error) {
 
@@ -49,7 +52,7 @@ func Create() (publicKey string, privateKey string, address string, err error) {
 	if err != nil {
 		return "", "", "", err
 	}
-	address, err = encodeAddress(public)
+	address, err = encodeAddress(public, AddressPrefix)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -78,6 +81,11 @@ func GetEncPublicKey(privateKey string) (publicKey string, err error) {
 
 //The public key gets the address
 func GetEncAddress(publicKey string) (address string, err error) {
+	return GetEncAddressWithPrefix(publicKey, AddressPrefix)
+}
+
+//The public key gets the address with the leading bytes of a chain
+func GetEncAddressWithPrefix(publicKey string, prefix []byte) (address string, err error) {
 	if CheckPublicKey(publicKey) == false {
 		return "", errors.New("publicKey error")
 	}
@@ -86,7 +94,7 @@ func GetEncAddress(publicKey string) (address string, err error) {
 		return "", err
 	}
 
-	return encodeAddress(PublicKey)
+	return encodeAddress(PublicKey, prefix)
 }
 
 //Verify the public key
@@ -167,26 +175,31 @@ func CheckPrivateKey(SprivateKey string) bool {
 
 //Verify the address key
 func CheckAddress(Saddress string) bool {
-	if Saddress == "" {
+	return CheckAddressWithPrefix(Saddress, AddressPrefix)
+}
+
+//Verify the address key with the leading bytes of a chain
+func CheckAddressWithPrefix(Saddress string, prefix []byte) bool {
+	if Saddress == "" || len(prefix) == 0 {
 		return false
 	}
 	var addre []byte
 	var ret bool
 	var err error
 	addre, err = base58.Decode(Saddress)
-	if err != nil {
+	if err != nil || len(addre) != len(prefix)+DeAddressSize+5 {
 		return false
 	}
 
-	if !(addre[0] == 0X01 && addre[1] == 0X56) {
+	if !bytes.Equal(addre[:len(prefix)], prefix) {
 
 		return false
-	} else if !(addre[2] == 1) {
+	} else if !(addre[len(prefix)] == 1) {
 		return false
 	}
 	var hash1, hash2 []byte
 
-	daddr := addre[:DeAddressSize+3]
+	daddr := addre[:len(prefix)+DeAddressSize+1]
 
 	h1 := sha256.New()
 	h1.Write([]byte(daddr))
@@ -196,7 +209,7 @@ func CheckAddress(Saddress string) bool {
 	h2.Write([]byte(hash1))
 	hash2 = h2.Sum(nil)
 
-	if !(hash2[0] == addre[DeAddressSize+3] && hash2[1] == addre[DeAddressSize+4] && hash2[2] == addre[DeAddressSize+5] && hash2[3] == addre[DeAddressSize+6]) {
+	if !bytes.Equal(hash2[:4], addre[len(daddr):]) {
 		return false
 	}
 	ret = true
@@ -298,16 +311,18 @@ func encodePrivateKey(privateKey *[PublicKeySize]byte) (GPrivateKey string, err
 }
 
 //Encoding address
-func encodeAddress(publicKey *[PublicKeySize]byte) (GAccoun string, err error) {
+func encodeAddress(publicKey *[PublicKeySize]byte, prefix []byte) (GAccoun string, err error) {
 	if publicKey == nil {
 		return "", errors.New("encode publicKey is error")
 	}
+	if len(prefix) == 0 {
+		return "", errors.New("address prefix is empty")
+	}
 	var ppbilc [PublicKeySize]byte = *publicKey
 
 	var str_result []byte
 	var hash1, hash2, pubSha []byte
-	str_result = append(str_result, 0X01)
-	str_result = append(str_result, 0X56)
+	str_result = append(str_result, prefix...)
 	str_result = append(str_result, 1)
 	var ppbilc1 []byte
 	ppbilc1 = ppbilc[:]
@@ -0,0 +1,287 @@
+// merkle
+//
+// The account trie and its proofs follow a layout of this SDK, not the one the
+// node uses for the account_tree_hash of a ledger, so a root or a proof from
+// this package is only comparable with another one built by this package.
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/golang/protobuf/proto"
+)
+
+// Hash
+func Hash(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// AccountKey is the trie location of an account: one nibble per byte of
+// sha256(address), so every key has the same length and no key is a prefix of
+// another.
+func AccountKey(address string) []byte {
+	sum := sha256.Sum256([]byte(address))
+	key := make([]byte, 0, len(sum)*2)
+	for i := range sum {
+		key = append(key, sum[i]>>4, sum[i]&0x0f)
+	}
+	return key
+}
+
+// AccountLeafHash commits to the whole serialized account, including balance,
+// nonce and the metadatas hash.
+func AccountLeafHash(account *protocol.Account) ([]byte, error) {
+	if account == nil || account.GetAddress() == "" {
+		return nil, errors.New("account is nil")
+	}
+	data, err := proto.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+	return Hash(data), nil
+}
+
+// NodeHash
+func NodeHash(node *protocol.Node) ([]byte, error) {
+	data, err := proto.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	return Hash(data), nil
+}
+
+// Trie is an in-memory account trie. It can compute the root hash and build
+// proofs, which is mostly useful for producing fixtures and for auditors that
+// rebuild a tree from an exported account set. Its root is not the
+// account_tree_hash the node computes for the same accounts.
+type Trie struct {
+	leaves map[string][]byte
+}
+
+// NewTrie
+func NewTrie() *Trie {
+	return &Trie{leaves: make(map[string][]byte)}
+}
+
+// SetAccount
+func (trie *Trie) SetAccount(account *protocol.Account) error {
+	leafHash, err := AccountLeafHash(account)
+	if err != nil {
+		return err
+	}
+	trie.leaves[string(AccountKey(account.GetAddress()))] = leafHash
+	return nil
+}
+
+// RootHash
+func (trie *Trie) RootHash() ([]byte, error) {
+	return NodeHash(trie.node(trie.sortedKeys(), 0))
+}
+
+// Prove returns the nodes on the path from the root to the node holding the
+// account leaf, root first.
+func (trie *Trie) Prove(address string) ([]*protocol.Node, error) {
+	key := AccountKey(address)
+	if _, ok := trie.leaves[string(key)]; !ok {
+		return nil, errors.New("account is not in the trie")
+	}
+	keys := trie.sortedKeys()
+	var proof []*protocol.Node
+	for depth := 0; depth < len(key); depth++ {
+		proof = append(proof, trie.node(keys, depth))
+		keys = group(keys, depth, key[depth])
+		if len(keys) == 1 {
+			return proof, nil
+		}
+	}
+	return nil, errors.New("account is not in the trie")
+}
+
+func (trie *Trie) sortedKeys() []string {
+	keys := make([]string, 0, len(trie.leaves))
+	for key := range trie.leaves {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (trie *Trie) node(keys []string, depth int) *protocol.Node {
+	var node protocol.Node
+	for nibble := byte(0); nibble < 16; nibble++ {
+		children := group(keys, depth, nibble)
+		switch len(children) {
+		case 0:
+			continue
+		case 1:
+			node.Children = append(node.Children, &protocol.Child{
+				Sublocation: []byte(children[0]),
+				Hash:        trie.leaves[children[0]],
+				Childtype:   protocol.CHILDTYPE_LEAF,
+			})
+		default:
+			// the keys are already validated, so marshalling cannot fail here
+			hash, _ := NodeHash(trie.node(children, depth+1))
+			node.Children = append(node.Children, &protocol.Child{
+				Sublocation: []byte(children[0][:depth+1]),
+				Hash:        hash,
+				Childtype:   protocol.CHILDTYPE_INNER,
+			})
+		}
+	}
+	return &node
+}
+
+func group(keys []string, depth int, nibble byte) []string {
+	var children []string
+	for i := range keys {
+		if keys[i][depth] == nibble {
+			children = append(children, keys[i])
+		}
+	}
+	return children
+}
+
+// VerifyAccountProof checks that the account is a leaf of the trie whose root
+// hash is rootHash, a root built with Trie.
+func VerifyAccountProof(rootHash []byte, account *protocol.Account, proof []*protocol.Node) (bool, error) {
+	leafHash, err := AccountLeafHash(account)
+	if err != nil {
+		return false, err
+	}
+	if len(proof) == 0 {
+		return false, errors.New("proof is empty")
+	}
+	key := AccountKey(account.GetAddress())
+	if len(proof) > len(key) {
+		return false, nil
+	}
+	expected := rootHash
+	for depth := range proof {
+		hash, err := NodeHash(proof[depth])
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(hash, expected) {
+			return false, nil
+		}
+		child := findChild(proof[depth], key, depth)
+		if child == nil {
+			return false, nil
+		}
+		if depth == len(proof)-1 {
+			return child.GetChildtype() == protocol.CHILDTYPE_LEAF &&
+				bytes.Equal(child.GetSublocation(), key) &&
+				bytes.Equal(child.GetHash(), leafHash), nil
+		}
+		if child.GetChildtype() != protocol.CHILDTYPE_INNER {
+			return false, nil
+		}
+		expected = child.GetHash()
+	}
+	return false, nil
+}
+
+func findChild(node *protocol.Node, key []byte, depth int) *protocol.Child {
+	for _, child := range node.GetChildren() {
+		location := child.GetSublocation()
+		if len(location) <= depth || location[depth] != key[depth] {
+			continue
+		}
+		if bytes.HasPrefix(key, location) {
+			return child
+		}
+	}
+	return nil
+}
+
+// EncodeProof hex encodes the serialized proof nodes so that a proof can be
+// carried in JSON or in a request.
+func EncodeProof(proof []*protocol.Node) ([]string, error) {
+	encoded := make([]string, len(proof))
+	for i := range proof {
+		data, err := proto.Marshal(proof[i])
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = hex.EncodeToString(data)
+	}
+	return encoded, nil
+}
+
+// DecodeProof
+func DecodeProof(encoded []string) ([]*protocol.Node, error) {
+	proof := make([]*protocol.Node, len(encoded))
+	for i := range encoded {
+		data, err := hex.DecodeString(encoded[i])
+		if err != nil {
+			return nil, err
+		}
+		proof[i] = new(protocol.Node)
+		err = proto.Unmarshal(data, proof[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proof, nil
+}
+
+// TransactionHash is the hash of the serialized transaction, the same value
+// the node reports as the transaction hash.
+func TransactionHash(transaction *protocol.Transaction) ([]byte, error) {
+	if transaction == nil {
+		return nil, errors.New("transaction is nil")
+	}
+	data, err := proto.Marshal(transaction)
+	if err != nil {
+		return nil, err
+	}
+	return Hash(data), nil
+}
+
+// TransactionTreeHash is the binary merkle root over the hashes of the
+// transactions, in ledger order.
+func TransactionTreeHash(envs []*protocol.TransactionEnv) ([]byte, error) {
+	hashes := make([][]byte, len(envs))
+	for i := range envs {
+		hash, err := TransactionHash(envs[i].GetTransaction())
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+	return TreeHash(hashes), nil
+}
+
+// TreeHash is the binary merkle root over the leaf hashes. A level with an
+// odd number of hashes pairs its last hash with itself, and an empty tree
+// hashes to sha256 of nothing. The ledger header has no transaction tree
+// hash, so this tree is the SDK's own and is compared with trusted roots
+// built the same way.
+func TreeHash(hashes [][]byte) []byte {
+	if len(hashes) == 0 {
+		return Hash(nil)
+	}
+	level := hashes
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			pair := make([]byte, 0, len(level[i])+len(right))
+			pair = append(pair, level[i]...)
+			pair = append(pair, right...)
+			next = append(next, Hash(pair))
+		}
+		level = next
+	}
+	return level[0]
+}
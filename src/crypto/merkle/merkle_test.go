@@ -0,0 +1,201 @@
+// merkle_test
+package merkle_test
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/merkle"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/golang/protobuf/proto"
+)
+
+var testAccounts = []*protocol.Account{
+	{Address: "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo", Nonce: 12, Balance: 999887000},
+	{Address: "buQBjJD1BSJ7nzAbzdTenAhpFjmxRVEEtmxH", Nonce: 1, Balance: 20000000},
+	{Address: "buQtfFxpQP9JCFgmu4WBojBbEnVyQGaJDgGn", Nonce: 0, Balance: 100000000, MetadatasHash: merkle.Hash([]byte("global_attribute"))},
+	{Address: "buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG", Nonce: 3, Balance: 1},
+	{Address: "buQqzdS9YSnokDjvzg4YaNatcFQfkgXqk6ss", Nonce: 0, Balance: 500000000000},
+}
+
+func newTestTrie(t *testing.T) *merkle.Trie {
+	trie := merkle.NewTrie()
+	for i := range testAccounts {
+		err := trie.SetAccount(testAccounts[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return trie
+}
+
+//prove and verify every account in the fixture
+func Test_VerifyAccountProof(t *testing.T) {
+	trie := newTestTrie(t)
+	root, err := trie.RootHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range testAccounts {
+		proof, err := trie.Prove(testAccounts[i].Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		isValid, err := merkle.VerifyAccountProof(root, testAccounts[i], proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isValid {
+			t.Errorf("proof of %s is rejected", testAccounts[i].Address)
+		}
+	}
+}
+
+//a larger tree has inner nodes on the proof path
+func Test_VerifyAccountProof_Deep(t *testing.T) {
+	trie := merkle.NewTrie()
+	var accounts []*protocol.Account
+	for i := 0; i < 300; i++ {
+		account := &protocol.Account{Address: "account" + strconv.Itoa(i), Nonce: int64(i), Balance: int64(i) * 1000}
+		accounts = append(accounts, account)
+		trie.SetAccount(account)
+	}
+	root, _ := trie.RootHash()
+	deepest := 0
+	for i := range accounts {
+		proof, err := trie.Prove(accounts[i].Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(proof) > deepest {
+			deepest = len(proof)
+		}
+		isValid, err := merkle.VerifyAccountProof(root, accounts[i], proof)
+		if err != nil || !isValid {
+			t.Errorf("proof of %s is rejected", accounts[i].Address)
+		}
+	}
+	if deepest < 3 {
+		t.Error("proofs are too short:", deepest)
+	}
+}
+
+//a changed balance or nonce must not verify against the original root
+func Test_VerifyAccountProof_Tampered(t *testing.T) {
+	trie := newTestTrie(t)
+	root, _ := trie.RootHash()
+	proof, err := trie.Prove(testAccounts[0].Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := proto.Clone(testAccounts[0]).(*protocol.Account)
+	tampered.Balance++
+	isValid, _ := merkle.VerifyAccountProof(root, tampered, proof)
+	if isValid {
+		t.Error("tampered balance is accepted")
+	}
+	tampered = proto.Clone(testAccounts[0]).(*protocol.Account)
+	tampered.Nonce++
+	isValid, _ = merkle.VerifyAccountProof(root, tampered, proof)
+	if isValid {
+		t.Error("tampered nonce is accepted")
+	}
+	missing := &protocol.Account{Address: "buQhP94E8FjWDF3zfsxjqVQDeBypvzMrB3y3", Balance: 1}
+	isValid, _ = merkle.VerifyAccountProof(root, missing, proof)
+	if isValid {
+		t.Error("account outside the trie is accepted")
+	}
+	otherRoot := merkle.Hash([]byte("other"))
+	isValid, _ = merkle.VerifyAccountProof(otherRoot, testAccounts[0], proof)
+	if isValid {
+		t.Error("proof is accepted against another root")
+	}
+}
+
+//the root must change with any account and not depend on insertion order
+func Test_RootHash(t *testing.T) {
+	trie := newTestTrie(t)
+	root, _ := trie.RootHash()
+	reversed := merkle.NewTrie()
+	for i := len(testAccounts) - 1; i >= 0; i-- {
+		reversed.SetAccount(testAccounts[i])
+	}
+	reversedRoot, _ := reversed.RootHash()
+	if !bytes.Equal(root, reversedRoot) {
+		t.Error("root hash depends on insertion order")
+	}
+	changed := proto.Clone(testAccounts[2]).(*protocol.Account)
+	changed.Balance = 0
+	reversed.SetAccount(changed)
+	changedRoot, _ := reversed.RootHash()
+	if bytes.Equal(root, changedRoot) {
+		t.Error("root hash does not change with the account state")
+	}
+}
+
+//proofs survive the hex encoding used by the requests
+func Test_EncodeProof(t *testing.T) {
+	trie := newTestTrie(t)
+	root, _ := trie.RootHash()
+	proof, _ := trie.Prove(testAccounts[3].Address)
+	encoded, err := merkle.EncodeProof(proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := merkle.DecodeProof(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isValid, err := merkle.VerifyAccountProof(root, testAccounts[3], decoded)
+	if err != nil || !isValid {
+		t.Error("decoded proof is rejected", err)
+	}
+	_, err = merkle.DecodeProof([]string{"zz"})
+	if err == nil {
+		t.Error("invalid hex is accepted")
+	}
+}
+
+func Test_Prove_Missing(t *testing.T) {
+	trie := newTestTrie(t)
+	_, err := trie.Prove("buQhP94E8FjWDF3zfsxjqVQDeBypvzMrB3y3")
+	if err == nil {
+		t.Error("proof of a missing account is built")
+	}
+	_, err = merkle.VerifyAccountProof(merkle.Hash(nil), testAccounts[0], nil)
+	if err == nil {
+		t.Error("empty proof is accepted")
+	}
+}
+
+//odd levels pair the last hash with itself
+func Test_TreeHash(t *testing.T) {
+	a := merkle.Hash([]byte("a"))
+	b := merkle.Hash([]byte("b"))
+	c := merkle.Hash([]byte("c"))
+	if !bytes.Equal(merkle.TreeHash(nil), merkle.Hash(nil)) {
+		t.Error("empty tree hash is wrong")
+	}
+	if !bytes.Equal(merkle.TreeHash([][]byte{a}), a) {
+		t.Error("single leaf is not the root")
+	}
+	ab := merkle.Hash(append(append([]byte{}, a...), b...))
+	cc := merkle.Hash(append(append([]byte{}, c...), c...))
+	root := merkle.Hash(append(append([]byte{}, ab...), cc...))
+	if !bytes.Equal(merkle.TreeHash([][]byte{a, b, c}), root) {
+		t.Error("tree hash of three leaves is wrong")
+	}
+	if bytes.Equal(merkle.TreeHash([][]byte{b, a, c}), root) {
+		t.Error("tree hash does not depend on the order")
+	}
+	envs := []*protocol.TransactionEnv{{Transaction: &protocol.Transaction{SourceAddress: testAccounts[0].Address, Nonce: 13}}}
+	txRoot, err := merkle.TransactionTreeHash(envs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txHash, _ := merkle.TransactionHash(envs[0].Transaction)
+	if !bytes.Equal(txRoot, txHash) {
+		t.Error("transaction tree hash is wrong")
+	}
+}
@@ -66,6 +66,32 @@ const (
 	GET_ALLOWANCE_ERROR                       int = 11065
 	GET_TOKEN_INFO_ERROR                      int = 11066
 	SIGNATURE_EMPTY_ERROR                     int = 11067
+	SNAPSHOT_NOT_FOUND_ERROR                  int = 11069
+	HISTORY_STORE_NULL_ERROR                  int = 11070
+	INVALID_PAGINATION_ERROR                  int = 11071
+	INVALID_EXPIRYTIME_ERROR                  int = 11072
+	GASPRICE_BELOW_MINIMUM_ERROR              int = 11073
+	INVALID_CONTRACT_INTERFACE_ERROR          int = 11074
+	CONTRACT_METHOD_NOT_FOUND_ERROR           int = 11075
+	INVALID_CONTRACT_ARGUMENT_ERROR           int = 11076
+	CONTRACT_QUERY_ERROR                      int = 11077
+	INVALID_PAYLOAD_SIZE_ERROR                int = 11078
+	CONTRACT_SYNTAX_ERROR                     int = 11079
+	READ_PAYLOAD_FILE_ERROR                   int = 11080
+	INVALID_INITINPUT_ERROR                   int = 11081
+	TRANSACTION_TIMEOUT_ERROR                 int = 11082
+	INVALID_NETWORK_ERROR                     int = 11083
+	INVALID_TOKEN_STANDARD_ERROR              int = 11084
+	UNKNOWN_TOKEN_STANDARD_ERROR              int = 11085
+	INVALID_ATP10_METADATA_ERROR              int = 11086
+	ATP10_SUPPLY_EXCEEDED_ERROR               int = 11087
+	ATP10_ASSET_EXISTS_ERROR                  int = 11088
+	ATP10_ASSET_NOT_FOUND_ERROR               int = 11089
+	INVALID_PAYOUT_RECIPIENT_ERROR            int = 11090
+	PAYOUT_JOURNAL_ERROR                      int = 11091
+	SWEEP_INSUFFICIENT_BALANCE_ERROR          int = 11092
+	ACCOUNT_ACTIVATED_ERROR                   int = 11093
+	INVALID_METADATA_JSON_ERROR               int = 11094
 	SYSTEM_ERROR                              int = 20000
 )
 const (
@@ -137,6 +163,32 @@ var errm = map[int]string{
 	SOURCEADDRESS_EQUAL_CONTRACTADDRESS_ERROR: "SourceAddress cannot be equal to contractAddress.",
 	FROMADDRESS_EQUAL_DESTADDRESS_ERROR:       "FromAddress cannot be equal to destAddress",
 	GET_ALLOWANCE_ERROR:                       "Get allowance failed",
+	SNAPSHOT_NOT_FOUND_ERROR:                  "No snapshot of the account at the ledger.",
+	HISTORY_STORE_NULL_ERROR:                  "The history store is not set.",
+	INVALID_PAGINATION_ERROR:                  "Offset must not be negative and limit must be between 0 and 1000.",
+	INVALID_EXPIRYTIME_ERROR:                  "ExpiryTime must be at least one ledger interval after the latest ledger.",
+	GASPRICE_BELOW_MINIMUM_ERROR:              "The gas price is below the minimum gas price of the chain.",
+	INVALID_CONTRACT_INTERFACE_ERROR:          "Invalid contract interface",
+	CONTRACT_METHOD_NOT_FOUND_ERROR:           "The method is not in the contract interface",
+	INVALID_CONTRACT_ARGUMENT_ERROR:           "The arguments do not match the method of the contract interface",
+	CONTRACT_QUERY_ERROR:                      "The contract query failed",
+	INVALID_PAYLOAD_SIZE_ERROR:                "The contract payload is larger than the limit",
+	CONTRACT_SYNTAX_ERROR:                     "The contract payload has a syntax error",
+	READ_PAYLOAD_FILE_ERROR:                   "Failed to read the contract payload file",
+	INVALID_INITINPUT_ERROR:                   "The init function of the contract failed with the init input",
+	TRANSACTION_TIMEOUT_ERROR:                 "The transaction was not confirmed before the timeout",
+	INVALID_NETWORK_ERROR:                     "Invalid network profile",
+	INVALID_TOKEN_STANDARD_ERROR:              "Invalid token standard",
+	UNKNOWN_TOKEN_STANDARD_ERROR:              "The contract implements no registered token standard",
+	INVALID_ATP10_METADATA_ERROR:              "Invalid ATP10 asset metadata",
+	ATP10_SUPPLY_EXCEEDED_ERROR:               "The issuance exceeds the total supply of the asset",
+	ATP10_ASSET_EXISTS_ERROR:                  "The asset already has ATP10 metadata",
+	ATP10_ASSET_NOT_FOUND_ERROR:               "The asset has no ATP10 metadata",
+	INVALID_PAYOUT_RECIPIENT_ERROR:            "Invalid payout recipient",
+	PAYOUT_JOURNAL_ERROR:                      "Failed to read or write the payout journal",
+	SWEEP_INSUFFICIENT_BALANCE_ERROR:          "The balance does not cover the base reserve and the fee of the sweep",
+	ACCOUNT_ACTIVATED_ERROR:                   "The account is already activated",
+	INVALID_METADATA_JSON_ERROR:               "The metadata value is not valid JSON",
 
 	GET_ENCPUBLICKEY_ERROR: "The function 'GetEncPublicKey' failed.",
 	SIGN_ERROR:             "The function 'Sign' failed.",
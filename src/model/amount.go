@@ -0,0 +1,305 @@
+// amount
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// BU_DECIMALS is the number of decimals of BU: 1 BU is 10^8 MO.
+const BU_DECIMALS int = 8
+
+// MAX_AMOUNT_DECIMALS is the largest number of decimals an Amount may have.
+const MAX_AMOUNT_DECIMALS int = 18
+
+var (
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrInvalidDecimals  = errors.New("decimals must be between 0 and 18")
+	ErrAmountPrecision  = errors.New("amount has more decimal places than allowed")
+	ErrDecimalsMismatch = errors.New("amounts have different decimals")
+	ErrAmountOverflow   = errors.New("amount does not fit in int64 base units")
+	ErrDivisionByZero   = errors.New("division by zero")
+)
+
+// Amount is an exact decimal value with a fixed number of decimals, kept as an
+// arbitrary precision count of base units: 1.5 BU is 150000000 units with 8
+// decimals. The zero value is 0 with 0 decimals. Amounts are immutable.
+type Amount struct {
+	units    *big.Int
+	decimals int
+}
+
+// NewAmount makes an amount of units base units with the given decimals.
+func NewAmount(units int64, decimals int) Amount {
+	return Amount{units: big.NewInt(units), decimals: decimals}
+}
+
+// NewAmountFromBig makes an amount of units base units with the given decimals.
+func NewAmountFromBig(units *big.Int, decimals int) Amount {
+	return Amount{units: new(big.Int).Set(units), decimals: decimals}
+}
+
+// NewBUAmount makes an amount of BU from MO.
+func NewBUAmount(mo int64) Amount {
+	return NewAmount(mo, BU_DECIMALS)
+}
+
+// ParseAmount parses a decimal string such as "12.345" or "-0.5" into an
+// amount with the given decimals. More decimal places than decimals is an
+// error unless the extra digits are zeros; exponents are not accepted.
+func ParseAmount(value string, decimals int) (Amount, error) {
+	if decimals < 0 || decimals > MAX_AMOUNT_DECIMALS {
+		return Amount{}, ErrInvalidDecimals
+	}
+	digits := value
+	negative := strings.HasPrefix(digits, "-")
+	if negative {
+		digits = digits[1:]
+	}
+	integer, fraction := digits, ""
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		integer, fraction = digits[:dot], digits[dot+1:]
+		if fraction == "" {
+			return Amount{}, ErrInvalidAmount
+		}
+	}
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return Amount{}, ErrInvalidAmount
+	}
+	if len(fraction) > decimals {
+		if strings.Trim(fraction[decimals:], "0") != "" {
+			return Amount{}, ErrAmountPrecision
+		}
+		fraction = fraction[:decimals]
+	}
+	fraction += strings.Repeat("0", decimals-len(fraction))
+	units, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return Amount{}, ErrInvalidAmount
+	}
+	if negative {
+		units.Neg(units)
+	}
+	return Amount{units: units, decimals: decimals}, nil
+}
+
+// ParseBU parses a number of BU such as "0.01".
+func ParseBU(value string) (Amount, error) {
+	return ParseAmount(value, BU_DECIMALS)
+}
+
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (amount Amount) int() *big.Int {
+	if amount.units == nil {
+		return new(big.Int)
+	}
+	return amount.units
+}
+
+// Decimals
+func (amount Amount) Decimals() int {
+	return amount.decimals
+}
+
+// BaseUnits returns a copy of the number of base units.
+func (amount Amount) BaseUnits() *big.Int {
+	return new(big.Int).Set(amount.int())
+}
+
+// Units returns the number of base units, or ErrAmountOverflow when it does
+// not fit in an int64 as the node expects.
+func (amount Amount) Units() (int64, error) {
+	if !amount.int().IsInt64() {
+		return 0, ErrAmountOverflow
+	}
+	return amount.int().Int64(), nil
+}
+
+// RescaledUnits returns the number of base units of the amount in other
+// decimals, e.g. those of a token. Dropping decimal places that are not zeros
+// fails with ErrAmountPrecision.
+func (amount Amount) RescaledUnits(decimals int) (int64, error) {
+	rescaled, err := amount.Rescale(decimals)
+	if err != nil {
+		return 0, err
+	}
+	return rescaled.Units()
+}
+
+// String formats the amount without trailing zeros, e.g. "1.5" or "-3".
+func (amount Amount) String() string {
+	return amount.format(true)
+}
+
+// format writes the decimal places of the amount, all of them unless trim is
+// set, when the trailing zeros are dropped.
+func (amount Amount) format(trim bool) string {
+	units := amount.int()
+	digits := new(big.Int).Abs(units).String()
+	if amount.decimals > 0 {
+		if len(digits) <= amount.decimals {
+			digits = strings.Repeat("0", amount.decimals-len(digits)+1) + digits
+		}
+		point := len(digits) - amount.decimals
+		fraction := digits[point:]
+		if trim {
+			fraction = strings.TrimRight(fraction, "0")
+		}
+		digits = digits[:point]
+		if fraction != "" {
+			digits += "." + fraction
+		}
+	}
+	if units.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Sign returns -1, 0 or 1.
+func (amount Amount) Sign() int {
+	return amount.int().Sign()
+}
+
+// IsZero
+func (amount Amount) IsZero() bool {
+	return amount.Sign() == 0
+}
+
+// Neg
+func (amount Amount) Neg() Amount {
+	return Amount{units: new(big.Int).Neg(amount.int()), decimals: amount.decimals}
+}
+
+// Add fails with ErrDecimalsMismatch unless both amounts have the same decimals.
+func (amount Amount) Add(other Amount) (Amount, error) {
+	if amount.decimals != other.decimals {
+		return Amount{}, ErrDecimalsMismatch
+	}
+	return Amount{units: new(big.Int).Add(amount.int(), other.int()), decimals: amount.decimals}, nil
+}
+
+// Sub fails with ErrDecimalsMismatch unless both amounts have the same decimals.
+func (amount Amount) Sub(other Amount) (Amount, error) {
+	if amount.decimals != other.decimals {
+		return Amount{}, ErrDecimalsMismatch
+	}
+	return Amount{units: new(big.Int).Sub(amount.int(), other.int()), decimals: amount.decimals}, nil
+}
+
+// Mul multiplies the amount by an integer.
+func (amount Amount) Mul(factor int64) Amount {
+	return Amount{units: new(big.Int).Mul(amount.int(), big.NewInt(factor)), decimals: amount.decimals}
+}
+
+// Quo divides the amount by an integer in base units, truncating toward zero,
+// and returns the quotient and the remainder.
+func (amount Amount) Quo(divisor int64) (Amount, Amount, error) {
+	if divisor == 0 {
+		return Amount{}, Amount{}, ErrDivisionByZero
+	}
+	quotient, remainder := new(big.Int).QuoRem(amount.int(), big.NewInt(divisor), new(big.Int))
+	return Amount{units: quotient, decimals: amount.decimals}, Amount{units: remainder, decimals: amount.decimals}, nil
+}
+
+// Cmp returns -1, 0 or 1 as the amount is less than, equal to or greater than
+// other, and fails with ErrDecimalsMismatch unless both have the same decimals.
+func (amount Amount) Cmp(other Amount) (int, error) {
+	if amount.decimals != other.decimals {
+		return 0, ErrDecimalsMismatch
+	}
+	return amount.int().Cmp(other.int()), nil
+}
+
+// Rescale converts the amount to other decimals. Dropping decimal places that
+// are not zeros fails with ErrAmountPrecision.
+func (amount Amount) Rescale(decimals int) (Amount, error) {
+	if decimals < 0 || decimals > MAX_AMOUNT_DECIMALS {
+		return Amount{}, ErrInvalidDecimals
+	}
+	units := new(big.Int).Set(amount.int())
+	if decimals >= amount.decimals {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-amount.decimals)), nil)
+		return Amount{units: units.Mul(units, scale), decimals: decimals}, nil
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(amount.decimals-decimals)), nil)
+	units, remainder := units.QuoRem(units, scale, new(big.Int))
+	if remainder.Sign() != 0 {
+		return Amount{}, ErrAmountPrecision
+	}
+	return Amount{units: units, decimals: decimals}, nil
+}
+
+// MarshalJSON writes the amount as a decimal string with all its decimal
+// places, e.g. "1.50000000" for 1.5 BU, so that it reads back with the same
+// decimals.
+func (amount Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(amount.format(false))
+}
+
+// UnmarshalJSON reads a decimal string or number. An amount that already has
+// decimals, e.g. one made by NewBUAmount(0), keeps them; otherwise the
+// decimals are the decimal places of the value. null is 0.
+func (amount *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*amount = Amount{decimals: amount.decimals}
+		return nil
+	}
+	var value string
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		err := json.Unmarshal(data, &value)
+		if err != nil {
+			return err
+		}
+	} else {
+		value = string(data)
+	}
+	decimals := amount.decimals
+	if decimals == 0 {
+		if dot := strings.IndexByte(value, '.'); dot >= 0 {
+			decimals = len(value) - dot - 1
+		}
+	}
+	parsed, err := ParseAmount(value, decimals)
+	if err != nil {
+		return err
+	}
+	*amount = parsed
+	return nil
+}
+
+// BalanceAmount is the balance as an amount of BU.
+func (result AccountGetBalanceResult) BalanceAmount() Amount {
+	return NewBUAmount(result.Balance)
+}
+
+// BalanceAmount is the balance as an amount of BU.
+func (result AccountGetInfoResult) BalanceAmount() Amount {
+	return NewBUAmount(result.Balance)
+}
+
+// TotalSupplyAmount is the total supply in base units with the decimals of the token.
+func (result Ctp10TokenGetInfoResult) TotalSupplyAmount() Amount {
+	return NewAmount(result.TotalSupply, int(result.Decimals))
+}
+
+// Amount returns base units of the asset as an Amount in its decimals.
+func (metadata Atp10Metadata) Amount(units int64) Amount {
+	return NewAmount(units, int(metadata.Decimals))
+}
+
+// ParseAmount parses a display amount of the asset, e.g. "12.5".
+func (metadata Atp10Metadata) ParseAmount(value string) (Amount, error) {
+	return ParseAmount(value, int(metadata.Decimals))
+}
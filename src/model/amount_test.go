@@ -0,0 +1,175 @@
+// amount_test
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+func Test_ParseAmount(t *testing.T) {
+	cases := []struct {
+		value    string
+		decimals int
+		units    string
+		err      error
+	}{
+		{"1", 8, "100000000", nil},
+		{"0.00000001", 8, "1", nil},
+		{"-1.5", 8, "-150000000", nil},
+		{"1.500000000", 8, "150000000", nil},
+		{"123456789012345678901234567890", 0, "123456789012345678901234567890", nil},
+		{"0.000000001", 8, "", model.ErrAmountPrecision},
+		{"1.", 8, "", model.ErrInvalidAmount},
+		{".5", 8, "", model.ErrInvalidAmount},
+		{"1e8", 8, "", model.ErrInvalidAmount},
+		{"", 8, "", model.ErrInvalidAmount},
+		{"1", 19, "", model.ErrInvalidDecimals},
+	}
+	for _, c := range cases {
+		amount, err := model.ParseAmount(c.value, c.decimals)
+		if err != c.err {
+			t.Errorf("%q: got error %v, want %v", c.value, err, c.err)
+			continue
+		}
+		if err == nil && amount.BaseUnits().String() != c.units {
+			t.Errorf("%q: got %s units, want %s", c.value, amount.BaseUnits(), c.units)
+		}
+	}
+}
+
+func Test_AmountString(t *testing.T) {
+	cases := map[string]model.Amount{
+		"1.5":        model.NewBUAmount(150000000),
+		"0.00000001": model.NewBUAmount(1),
+		"-0.1":       model.NewBUAmount(-10000000),
+		"0":          model.NewBUAmount(0),
+		"42":         model.NewAmount(42, 0),
+	}
+	for want, amount := range cases {
+		if amount.String() != want {
+			t.Errorf("got %s, want %s", amount, want)
+		}
+	}
+	if (model.Amount{}).String() != "0" {
+		t.Error("zero value is not 0")
+	}
+}
+
+func Test_AmountArithmetic(t *testing.T) {
+	a, _ := model.ParseBU("1.25")
+	b, _ := model.ParseBU("0.75")
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "2" {
+		t.Error("sum is", sum, err)
+	}
+	difference, _ := b.Sub(a)
+	if difference.String() != "-0.5" || difference.Sign() != -1 {
+		t.Error("difference is", difference)
+	}
+	if c, _ := a.Cmp(b); c != 1 {
+		t.Error("1.25 is not greater than 0.75")
+	}
+	token := model.NewAmount(1, 2)
+	if _, err := a.Add(token); err != model.ErrDecimalsMismatch {
+		t.Error("BU and token amounts are added")
+	}
+	quotient, remainder, _ := model.NewBUAmount(10).Quo(3)
+	if quotient.String() != "0.00000003" || remainder.String() != "0.00000001" {
+		t.Error("quotient is", quotient, remainder)
+	}
+	if _, _, err := a.Quo(0); err != model.ErrDivisionByZero {
+		t.Error("division by zero is accepted")
+	}
+	if rescaled, err := a.Rescale(2); err != nil || rescaled.String() != "1.25" {
+		t.Error("rescaled is", rescaled, err)
+	}
+	if _, err := model.NewBUAmount(1).Rescale(2); err != model.ErrAmountPrecision {
+		t.Error("precision is lost")
+	}
+	big, _ := model.ParseAmount("100000000000", 8)
+	if _, err := big.Units(); err != model.ErrAmountOverflow {
+		t.Error("overflow is not reported")
+	}
+}
+
+func Test_AmountJSON(t *testing.T) {
+	data, err := json.Marshal(struct{ Value model.Amount }{model.NewBUAmount(150000000)})
+	if err != nil || string(data) != `{"Value":"1.50000000"}` {
+		t.Fatal(string(data), err)
+	}
+	amount := model.NewBUAmount(0)
+	err = json.Unmarshal([]byte(`"2.5"`), &amount)
+	if err != nil || amount.Decimals() != 8 || amount.BaseUnits().Int64() != 250000000 {
+		t.Error("unmarshalled", amount, err)
+	}
+	var inferred model.Amount
+	err = json.Unmarshal([]byte(`12.345`), &inferred)
+	if err != nil || inferred.Decimals() != 3 || inferred.String() != "12.345" {
+		t.Error("unmarshalled", inferred, err)
+	}
+	if json.Unmarshal([]byte(`"abc"`), &inferred) == nil {
+		t.Error("invalid amount is unmarshalled")
+	}
+	amount = model.NewBUAmount(5)
+	err = json.Unmarshal([]byte(`null`), &amount)
+	if err != nil || !amount.IsZero() || amount.Decimals() != 8 {
+		t.Error("null is", amount, err)
+	}
+}
+
+func Test_AmountJSONRoundTrip(t *testing.T) {
+	amounts := []model.Amount{
+		model.NewBUAmount(150000000),
+		model.NewBUAmount(-1),
+		model.NewBUAmount(0),
+		model.NewAmount(42, 0),
+		model.NewAmount(1234500, 6),
+		{},
+	}
+	for _, amount := range amounts {
+		data, err := json.Marshal(model.Ctp10TokenInfo{Ctp: "1.0", TotalSupply: amount})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var info model.Ctp10TokenInfo
+		err = json.Unmarshal(data, &info)
+		if err != nil {
+			t.Fatal(string(data), err)
+		}
+		if c, err := info.TotalSupply.Cmp(amount); err != nil || c != 0 {
+			t.Errorf("%s reads back as %s with %d decimals: %v", amount, info.TotalSupply, info.TotalSupply.Decimals(), err)
+		}
+	}
+}
+
+func Test_SetBUAmount(t *testing.T) {
+	var send model.BUSendOperation
+	amount, _ := model.ParseBU("0.01")
+	if err := send.SetBUAmount(amount); err != nil || send.GetAmount() != 1000000 {
+		t.Error("amount is", send.GetAmount(), err)
+	}
+	// 0.01 with 2 decimals is the same amount
+	if err := send.SetBUAmount(model.NewAmount(1, 2)); err != nil || send.GetAmount() != 1000000 {
+		t.Error("amount is", send.GetAmount(), err)
+	}
+	if err := send.SetBUAmount(model.NewAmount(1, 9)); err != model.ErrAmountPrecision {
+		t.Error("amount is rounded to the decimals of BU")
+	}
+	var activate model.AccountActivateOperation
+	if err := activate.SetInitBalanceAmount(model.NewAmount(2, 0)); err != nil || activate.GetInitBalance() != 200000000 {
+		t.Error("init balance is", activate.GetInitBalance(), err)
+	}
+	var transfer model.Ctp10TokenTransferOperation
+	if err := transfer.SetTokenAmount(model.NewAmount(1, 2), 2); err != nil || transfer.GetAmount() != 1 {
+		t.Error("token amount is", transfer.GetAmount(), err)
+	}
+	// 1.5 of a token with 8 decimals
+	if err := transfer.SetTokenAmount(model.NewAmount(15, 1), 8); err != nil || transfer.GetAmount() != 150000000 {
+		t.Error("token amount is", transfer.GetAmount(), err)
+	}
+	if err := transfer.SetTokenAmount(model.NewAmount(15, 1), 0); err != model.ErrAmountPrecision {
+		t.Error("amount is rounded to the decimals of the token")
+	}
+}
@@ -0,0 +1,106 @@
+// network
+package model
+
+import (
+	"encoding/hex"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+)
+
+// Network describes a chain: the addresses of its system contracts, the fees
+// used for contract queries and transactions that set none, and its known
+// limits. The zero value of a field takes the value of the main network, so a
+// profile for a private chain only sets what differs.
+type Network struct {
+	Name string `json:"name"`
+	// the validator election contract, which distributes the block rewards
+	RewardContract string `json:"reward_contract"`
+	// the fee limit and gas price sent with contract queries
+	QueryFeeLimit int64 `json:"query_fee_limit"`
+	QueryGasPrice int64 `json:"query_gas_price"`
+	// the gas price and fee limit of transactions built without them
+	GasPrice int64 `json:"gas_price"`
+	FeeLimit int64 `json:"fee_limit"`
+	// the largest contract payload, in bytes
+	MaxPayloadSize int `json:"max_payload_size"`
+	// the leading bytes of an encoded address, in hex, which give the
+	// addresses of the chain their first letters: 0156 gives bu
+	AddressPrefix string `json:"address_prefix"`
+}
+
+// names of the predefined networks
+const (
+	NETWORK_MAINNET string = "mainnet"
+	NETWORK_TESTNET string = "testnet"
+)
+
+// MainnetNetwork returns the profile of the BUMO main network.
+func MainnetNetwork() Network {
+	return Network{
+		Name:           NETWORK_MAINNET,
+		RewardContract: "buQqzdS9YSnokDjvzg4YaNatcFQfkgXqk6ss",
+		QueryFeeLimit:  100000000000,
+		QueryGasPrice:  10000,
+		GasPrice:       1000,
+		FeeLimit:       1000000,
+		MaxPayloadSize: 256 * 1024,
+		AddressPrefix:  hex.EncodeToString(keypair.AddressPrefix),
+	}
+}
+
+// TestnetNetwork returns the profile of the BUMO test network. Its genesis
+// creates the election contract at the same address as the main network.
+func TestnetNetwork() Network {
+	network := MainnetNetwork()
+	network.Name = NETWORK_TESTNET
+	return network
+}
+
+// Resolve returns the network with its zero fields taken from the main
+// network.
+func (network Network) Resolve() Network {
+	mainnet := MainnetNetwork()
+	if network.Name == "" {
+		network.Name = mainnet.Name
+	}
+	if network.RewardContract == "" {
+		network.RewardContract = mainnet.RewardContract
+	}
+	if network.QueryFeeLimit == 0 {
+		network.QueryFeeLimit = mainnet.QueryFeeLimit
+	}
+	if network.QueryGasPrice == 0 {
+		network.QueryGasPrice = mainnet.QueryGasPrice
+	}
+	if network.GasPrice == 0 {
+		network.GasPrice = mainnet.GasPrice
+	}
+	if network.FeeLimit == 0 {
+		network.FeeLimit = mainnet.FeeLimit
+	}
+	if network.MaxPayloadSize == 0 {
+		network.MaxPayloadSize = mainnet.MaxPayloadSize
+	}
+	if network.AddressPrefix == "" {
+		network.AddressPrefix = mainnet.AddressPrefix
+	}
+	return network
+}
+
+// CheckAddress checks an address, including its prefix.
+func (network Network) CheckAddress(address string) bool {
+	prefix, err := hex.DecodeString(network.Resolve().AddressPrefix)
+	if err != nil {
+		return false
+	}
+	return keypair.CheckAddressWithPrefix(address, prefix)
+}
+
+// EncodeAddress derives the address of a public key.
+func (network Network) EncodeAddress(publicKey string) (string, error) {
+	prefix, err := hex.DecodeString(network.Resolve().AddressPrefix)
+	if err != nil {
+		return "", err
+	}
+	return keypair.GetEncAddressWithPrefix(publicKey, prefix)
+}
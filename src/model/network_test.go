@@ -0,0 +1,52 @@
+// network_test
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+func Test_NetworkResolve(t *testing.T) {
+	if network := (model.Network{}).Resolve(); network != model.MainnetNetwork() {
+		t.Errorf("zero network resolves to %+v", network)
+	}
+	private := model.Network{Name: "private", RewardContract: "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo", MaxPayloadSize: 1024}
+	network := private.Resolve()
+	if network.Name != "private" || network.RewardContract != private.RewardContract || network.MaxPayloadSize != 1024 {
+		t.Errorf("overrides are lost: %+v", network)
+	}
+	if network.GasPrice != model.MainnetNetwork().GasPrice || network.QueryFeeLimit != model.MainnetNetwork().QueryFeeLimit {
+		t.Errorf("defaults are missing: %+v", network)
+	}
+	if model.TestnetNetwork().Name != model.NETWORK_TESTNET {
+		t.Error("wrong testnet name")
+	}
+	if network.AddressPrefix != "0156" {
+		t.Errorf("wrong address prefix %s", network.AddressPrefix)
+	}
+}
+
+func Test_NetworkAddressPrefix(t *testing.T) {
+	publicKey, _, mainnetAddress, _ := keypair.Create()
+	mainnet := model.Network{}
+	if address, err := mainnet.EncodeAddress(publicKey); err != nil || address != mainnetAddress || !mainnet.CheckAddress(address) {
+		t.Errorf("wrong mainnet address %s", address)
+	}
+	private := model.Network{Name: "private", AddressPrefix: "0105"}
+	address, err := private.EncodeAddress(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.HasPrefix(address, "bu") || !private.CheckAddress(address) {
+		t.Errorf("wrong private address %s", address)
+	}
+	if mainnet.CheckAddress(address) || private.CheckAddress(mainnetAddress) {
+		t.Error("address is accepted under another prefix")
+	}
+	if _, err = (model.Network{AddressPrefix: "zz"}).EncodeAddress(publicKey); err == nil {
+		t.Error("invalid prefix is accepted")
+	}
+}
@@ -3,6 +3,7 @@ package model
 
 import (
 	"container/list"
+	"time"
 )
 
 const Conversion float64 = 100000000
@@ -56,6 +57,143 @@ func (reqData *AccountGetBalanceRequest) GetAddress() string {
 	return reqData.address
 }
 
+//GetSnapshot
+type AccountGetSnapshotRequest struct {
+	address   string
+	ledgerSeq int64
+}
+
+func (reqData *AccountGetSnapshotRequest) SetAddress(Address string) {
+	reqData.address = Address
+}
+func (reqData *AccountGetSnapshotRequest) GetAddress() string {
+	return reqData.address
+}
+func (reqData *AccountGetSnapshotRequest) SetLedgerSeq(LedgerSeq int64) {
+	reqData.ledgerSeq = LedgerSeq
+}
+func (reqData *AccountGetSnapshotRequest) GetLedgerSeq() int64 {
+	return reqData.ledgerSeq
+}
+
+//ActivateBatch
+type AccountActivateBatchRequest struct {
+	sourceAddress    string
+	privateKeys      []string
+	destAddresses    []string
+	initBalance      int64
+	priv             *Priv
+	accountMetadatas []Metadata
+	metadata         string
+	gasPrice         int64
+	feeLimit         int64
+	timeout          time.Duration
+}
+
+func (reqData *AccountActivateBatchRequest) SetSourceAddress(SourceAddress string) {
+	reqData.sourceAddress = SourceAddress
+}
+func (reqData *AccountActivateBatchRequest) GetSourceAddress() string {
+	return reqData.sourceAddress
+}
+func (reqData *AccountActivateBatchRequest) SetPrivateKeys(PrivateKeys []string) {
+	reqData.privateKeys = PrivateKeys
+}
+func (reqData *AccountActivateBatchRequest) GetPrivateKeys() []string {
+	return reqData.privateKeys
+}
+func (reqData *AccountActivateBatchRequest) SetDestAddresses(DestAddresses []string) {
+	reqData.destAddresses = DestAddresses
+}
+func (reqData *AccountActivateBatchRequest) GetDestAddresses() []string {
+	return reqData.destAddresses
+}
+
+// SetInitBalance sets the balance of every new account, the base reserve of
+// the chain when it is 0.
+func (reqData *AccountActivateBatchRequest) SetInitBalance(InitBalance int64) {
+	reqData.initBalance = InitBalance
+}
+func (reqData *AccountActivateBatchRequest) GetInitBalance() int64 {
+	return reqData.initBalance
+}
+func (reqData *AccountActivateBatchRequest) SetPriv(Privilege Priv) {
+	reqData.priv = &Privilege
+}
+func (reqData *AccountActivateBatchRequest) GetPriv() *Priv {
+	return reqData.priv
+}
+func (reqData *AccountActivateBatchRequest) AddAccountMetadata(Key string, Value string) {
+	reqData.accountMetadatas = append(reqData.accountMetadatas, Metadata{Key: Key, Value: Value})
+}
+func (reqData *AccountActivateBatchRequest) GetAccountMetadatas() []Metadata {
+	return reqData.accountMetadatas
+}
+func (reqData *AccountActivateBatchRequest) SetMetadata(Metadata string) {
+	reqData.metadata = Metadata
+}
+func (reqData *AccountActivateBatchRequest) GetMetadata() string {
+	return reqData.metadata
+}
+func (reqData *AccountActivateBatchRequest) SetGasPrice(GasPrice int64) {
+	reqData.gasPrice = GasPrice
+}
+func (reqData *AccountActivateBatchRequest) GetGasPrice() int64 {
+	return reqData.gasPrice
+}
+func (reqData *AccountActivateBatchRequest) SetFeeLimit(FeeLimit int64) {
+	reqData.feeLimit = FeeLimit
+}
+func (reqData *AccountActivateBatchRequest) GetFeeLimit() int64 {
+	return reqData.feeLimit
+}
+func (reqData *AccountActivateBatchRequest) SetTimeout(Timeout time.Duration) {
+	reqData.timeout = Timeout
+}
+func (reqData *AccountActivateBatchRequest) GetTimeout() time.Duration {
+	return reqData.timeout
+}
+
+//GetTransactionHistory
+type AccountGetTransactionHistoryRequest struct {
+	address        string
+	startLedgerSeq int64
+	endLedgerSeq   int64
+	offset         int64
+	limit          int64
+}
+
+func (reqData *AccountGetTransactionHistoryRequest) SetAddress(Address string) {
+	reqData.address = Address
+}
+func (reqData *AccountGetTransactionHistoryRequest) GetAddress() string {
+	return reqData.address
+}
+func (reqData *AccountGetTransactionHistoryRequest) SetStartLedgerSeq(StartLedgerSeq int64) {
+	reqData.startLedgerSeq = StartLedgerSeq
+}
+func (reqData *AccountGetTransactionHistoryRequest) GetStartLedgerSeq() int64 {
+	return reqData.startLedgerSeq
+}
+func (reqData *AccountGetTransactionHistoryRequest) SetEndLedgerSeq(EndLedgerSeq int64) {
+	reqData.endLedgerSeq = EndLedgerSeq
+}
+func (reqData *AccountGetTransactionHistoryRequest) GetEndLedgerSeq() int64 {
+	return reqData.endLedgerSeq
+}
+func (reqData *AccountGetTransactionHistoryRequest) SetOffset(Offset int64) {
+	reqData.offset = Offset
+}
+func (reqData *AccountGetTransactionHistoryRequest) GetOffset() int64 {
+	return reqData.offset
+}
+func (reqData *AccountGetTransactionHistoryRequest) SetLimit(Limit int64) {
+	reqData.limit = Limit
+}
+func (reqData *AccountGetTransactionHistoryRequest) GetLimit() int64 {
+	return reqData.limit
+}
+
 //GetAssets
 type AccountGetAssetsRequest struct {
 	address string
@@ -261,6 +399,133 @@ func (reqData *ContractCallRequest) GetOptType() int64 {
 	return reqData.optType
 }
 
+//Simulate
+type ContractSimulateRequest struct {
+	call             ContractCallRequest
+	sourceBalance    int64
+	hasSourceBalance bool
+	metadatas        map[string]string
+	blockNumber      int64
+	blockTimestamp   int64
+}
+
+func (reqData *ContractSimulateRequest) SetCall(Call ContractCallRequest) {
+	reqData.call = Call
+}
+func (reqData *ContractSimulateRequest) GetCall() ContractCallRequest {
+	return reqData.call
+}
+func (reqData *ContractSimulateRequest) SetSourceBalance(SourceBalance int64) {
+	reqData.sourceBalance = SourceBalance
+	reqData.hasSourceBalance = true
+}
+func (reqData *ContractSimulateRequest) GetSourceBalance() (int64, bool) {
+	return reqData.sourceBalance, reqData.hasSourceBalance
+}
+func (reqData *ContractSimulateRequest) SetMetadata(Key string, Value string) {
+	if reqData.metadatas == nil {
+		reqData.metadatas = make(map[string]string)
+	}
+	reqData.metadatas[Key] = Value
+}
+func (reqData *ContractSimulateRequest) GetMetadatas() map[string]string {
+	return reqData.metadatas
+}
+func (reqData *ContractSimulateRequest) SetBlockNumber(BlockNumber int64) {
+	reqData.blockNumber = BlockNumber
+}
+func (reqData *ContractSimulateRequest) GetBlockNumber() int64 {
+	return reqData.blockNumber
+}
+func (reqData *ContractSimulateRequest) SetBlockTimestamp(BlockTimestamp int64) {
+	reqData.blockTimestamp = BlockTimestamp
+}
+func (reqData *ContractSimulateRequest) GetBlockTimestamp() int64 {
+	return reqData.blockTimestamp
+}
+
+//Deploy
+type ContractDeployRequest struct {
+	sourceAddress string
+	privateKeys   []string
+	payload       string
+	payloadFile   string
+	initBalance   int64
+	initInput     string
+	validateInit  bool
+	metadata      string
+	gasPrice      int64
+	feeLimit      int64
+	timeout       time.Duration
+}
+
+func (reqData *ContractDeployRequest) SetSourceAddress(SourceAddress string) {
+	reqData.sourceAddress = SourceAddress
+}
+func (reqData *ContractDeployRequest) GetSourceAddress() string {
+	return reqData.sourceAddress
+}
+func (reqData *ContractDeployRequest) SetPrivateKeys(PrivateKeys []string) {
+	reqData.privateKeys = PrivateKeys
+}
+func (reqData *ContractDeployRequest) GetPrivateKeys() []string {
+	return reqData.privateKeys
+}
+func (reqData *ContractDeployRequest) SetPayload(Payload string) {
+	reqData.payload = Payload
+}
+func (reqData *ContractDeployRequest) GetPayload() string {
+	return reqData.payload
+}
+func (reqData *ContractDeployRequest) SetPayloadFile(PayloadFile string) {
+	reqData.payloadFile = PayloadFile
+}
+func (reqData *ContractDeployRequest) GetPayloadFile() string {
+	return reqData.payloadFile
+}
+func (reqData *ContractDeployRequest) SetInitBalance(InitBalance int64) {
+	reqData.initBalance = InitBalance
+}
+func (reqData *ContractDeployRequest) GetInitBalance() int64 {
+	return reqData.initBalance
+}
+func (reqData *ContractDeployRequest) SetInitInput(InitInput string) {
+	reqData.initInput = InitInput
+}
+func (reqData *ContractDeployRequest) GetInitInput() string {
+	return reqData.initInput
+}
+func (reqData *ContractDeployRequest) SetValidateInit(ValidateInit bool) {
+	reqData.validateInit = ValidateInit
+}
+func (reqData *ContractDeployRequest) GetValidateInit() bool {
+	return reqData.validateInit
+}
+func (reqData *ContractDeployRequest) SetMetadata(Metadata string) {
+	reqData.metadata = Metadata
+}
+func (reqData *ContractDeployRequest) GetMetadata() string {
+	return reqData.metadata
+}
+func (reqData *ContractDeployRequest) SetGasPrice(GasPrice int64) {
+	reqData.gasPrice = GasPrice
+}
+func (reqData *ContractDeployRequest) GetGasPrice() int64 {
+	return reqData.gasPrice
+}
+func (reqData *ContractDeployRequest) SetFeeLimit(FeeLimit int64) {
+	reqData.feeLimit = FeeLimit
+}
+func (reqData *ContractDeployRequest) GetFeeLimit() int64 {
+	return reqData.feeLimit
+}
+func (reqData *ContractDeployRequest) SetTimeout(Timeout time.Duration) {
+	reqData.timeout = Timeout
+}
+func (reqData *ContractDeployRequest) GetTimeout() time.Duration {
+	return reqData.timeout
+}
+
 //GetAddress
 type ContractGetAddressRequest struct {
 	hash string
@@ -374,6 +639,25 @@ func (reqData *BlockGetRewardRequest) GetBlockNumber() int64 {
 	return reqData.blockNumber
 }
 
+//VerifyTransactions
+type BlockVerifyTransactionsRequest struct {
+	blockNumber         int64
+	transactionTreeHash string
+}
+
+func (reqData *BlockVerifyTransactionsRequest) SetBlockNumber(BlockNumber int64) {
+	reqData.blockNumber = BlockNumber
+}
+func (reqData *BlockVerifyTransactionsRequest) GetBlockNumber() int64 {
+	return reqData.blockNumber
+}
+func (reqData *BlockVerifyTransactionsRequest) SetTransactionTreeHash(TransactionTreeHash string) {
+	reqData.transactionTreeHash = TransactionTreeHash
+}
+func (reqData *BlockVerifyTransactionsRequest) GetTransactionTreeHash() string {
+	return reqData.transactionTreeHash
+}
+
 //GetFees
 type BlockGetFeesRequest struct {
 	blockNumber int64
@@ -504,7 +788,8 @@ func (reqData *Ctp10TokenGetBalanceRequest) GetTokenOwner() string {
 }
 
 type SDKInitRequest struct {
-	url string
+	url     string
+	network Network
 }
 
 func (reqData *SDKInitRequest) SetUrl(Url string) {
@@ -513,6 +798,29 @@ func (reqData *SDKInitRequest) SetUrl(Url string) {
 func (reqData *SDKInitRequest) GetUrl() string {
 	return reqData.url
 }
+func (reqData *SDKInitRequest) SetNetwork(Profile Network) {
+	reqData.network = Profile
+}
+func (reqData *SDKInitRequest) GetNetwork() Network {
+	return reqData.network
+}
+
+//ChainParams are the chain parameters needed to build a transaction offline
+type ChainParams struct {
+	GasPrice    int64 `json:"gas_price"`
+	BaseReserve int64 `json:"base_reserve"`
+	LedgerSeq   int64 `json:"ledger_seq"`
+	//close time of LedgerSeq and the average time between ledgers, in microseconds
+	CloseTime     int64 `json:"close_time"`
+	CloseInterval int64 `json:"close_interval"`
+}
+
+//expiry modes of a transaction
+const (
+	EXPIRY_RELATIVE int = iota
+	EXPIRY_ABSOLUTE
+	EXPIRY_TIME
+)
 
 //TransactionBuildBlob
 type TransactionBuildBlobRequest struct {
@@ -523,6 +831,9 @@ type TransactionBuildBlobRequest struct {
 	operations    list.List
 	metadata      string
 	ceilLedgerSeq int64
+	expiryMode    int
+	expiryTime    time.Time
+	chainParams   *ChainParams
 }
 
 func (reqData *TransactionBuildBlobRequest) SetSourceAddress(SourceAddress string) {
@@ -555,12 +866,39 @@ func (reqData *TransactionBuildBlobRequest) SetMetadata(Metadata string) {
 func (reqData *TransactionBuildBlobRequest) GetMetadata() string {
 	return reqData.metadata
 }
+//SetCeilLedgerSeq sets the expiry as an offset from the current ledger
 func (reqData *TransactionBuildBlobRequest) SetCeilLedgerSeq(CeilLedgerSeq int64) {
 	reqData.ceilLedgerSeq = CeilLedgerSeq
+	reqData.expiryMode = EXPIRY_RELATIVE
 }
 func (reqData *TransactionBuildBlobRequest) GetCeilLedgerSeq() int64 {
 	return reqData.ceilLedgerSeq
 }
+
+//SetAbsoluteCeilLedgerSeq sets the last ledger the transaction can be included in
+func (reqData *TransactionBuildBlobRequest) SetAbsoluteCeilLedgerSeq(CeilLedgerSeq int64) {
+	reqData.ceilLedgerSeq = CeilLedgerSeq
+	reqData.expiryMode = EXPIRY_ABSOLUTE
+}
+
+//SetExpiryTime sets the expiry as a wall-clock time, turned into a ledger from the observed close times
+func (reqData *TransactionBuildBlobRequest) SetExpiryTime(ExpiryTime time.Time) {
+	reqData.ceilLedgerSeq = 0
+	reqData.expiryTime = ExpiryTime
+	reqData.expiryMode = EXPIRY_TIME
+}
+func (reqData *TransactionBuildBlobRequest) GetExpiryTime() time.Time {
+	return reqData.expiryTime
+}
+func (reqData *TransactionBuildBlobRequest) GetExpiryMode() int {
+	return reqData.expiryMode
+}
+func (reqData *TransactionBuildBlobRequest) SetChainParams(Params ChainParams) {
+	reqData.chainParams = &Params
+}
+func (reqData *TransactionBuildBlobRequest) GetChainParams() *ChainParams {
+	return reqData.chainParams
+}
 func (reqData *TransactionBuildBlobRequest) SetOperation(operation BaseOperation) {
 	reqData.operations.Init()
 	reqData.operations.PushBack(operation)
@@ -578,11 +916,13 @@ type BaseOperation interface {
 
 //AccountActivate
 type AccountActivateOperation struct {
-	sourceAddress string
-	destAddress   string
-	initBalance   int64
-	metadata      string
-	operationType int
+	sourceAddress    string
+	destAddress      string
+	initBalance      int64
+	metadata         string
+	priv             *Priv
+	accountMetadatas []Metadata
+	operationType    int
 }
 
 func (reqData *AccountActivateOperation) SetSourceAddress(SourceAddress string) {
@@ -603,12 +943,37 @@ func (reqData *AccountActivateOperation) SetInitBalance(InitBalance int64) {
 func (reqData *AccountActivateOperation) GetInitBalance() int64 {
 	return reqData.initBalance
 }
+func (reqData *AccountActivateOperation) SetInitBalanceAmount(InitBalance Amount) error {
+	units, err := InitBalance.RescaledUnits(BU_DECIMALS)
+	if err != nil {
+		return err
+	}
+	reqData.initBalance = units
+	return nil
+}
 func (reqData *AccountActivateOperation) SetMetadata(Metadata string) {
 	reqData.metadata = Metadata
 }
 func (reqData *AccountActivateOperation) GetMetadata() string {
 	return reqData.metadata
 }
+
+// SetPriv sets the master weight, signers and thresholds of the new account,
+// a master weight and tx threshold of 1 when it is not set.
+func (reqData *AccountActivateOperation) SetPriv(Privilege Priv) {
+	reqData.priv = &Privilege
+}
+func (reqData *AccountActivateOperation) GetPriv() *Priv {
+	return reqData.priv
+}
+
+// AddAccountMetadata adds a metadata the new account starts with.
+func (reqData *AccountActivateOperation) AddAccountMetadata(Key string, Value string) {
+	reqData.accountMetadatas = append(reqData.accountMetadatas, Metadata{Key: Key, Value: Value})
+}
+func (reqData *AccountActivateOperation) GetAccountMetadatas() []Metadata {
+	return reqData.accountMetadatas
+}
 func (reqData *AccountActivateOperation) Init() {
 	reqData.operationType = 1
 }
@@ -751,6 +1116,14 @@ func (reqData *AssetIssueOperation) SetAmount(Amount int64) {
 func (reqData *AssetIssueOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *AssetIssueOperation) SetTokenAmount(AmountValue Amount, Decimals int) error {
+	units, err := AmountValue.RescaledUnits(Decimals)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *AssetIssueOperation) SetMetadata(Metadata string) {
 	reqData.metadata = Metadata
 }
@@ -793,6 +1166,14 @@ func (reqData *AssetSendOperation) SetAmount(Amount int64) {
 func (reqData *AssetSendOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *AssetSendOperation) SetTokenAmount(AmountValue Amount, Decimals int) error {
+	units, err := AmountValue.RescaledUnits(Decimals)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *AssetSendOperation) SetCode(Code string) {
 	reqData.code = Code
 }
@@ -845,6 +1226,14 @@ func (reqData *BUSendOperation) SetAmount(Amount int64) {
 func (reqData *BUSendOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *BUSendOperation) SetBUAmount(AmountValue Amount) error {
+	units, err := AmountValue.RescaledUnits(BU_DECIMALS)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *BUSendOperation) SetMetadata(Metadata string) {
 	reqData.metadata = Metadata
 }
@@ -953,6 +1342,14 @@ func (reqData *Ctp10TokenTransferOperation) SetAmount(Amount int64) {
 func (reqData *Ctp10TokenTransferOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *Ctp10TokenTransferOperation) SetTokenAmount(AmountValue Amount, Decimals int) error {
+	units, err := AmountValue.RescaledUnits(Decimals)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *Ctp10TokenTransferOperation) SetMetadata(Metadata string) {
 	reqData.metadata = Metadata
 }
@@ -1007,6 +1404,14 @@ func (reqData *Ctp10TokenTransferFromOperation) SetAmount(Amount int64) {
 func (reqData *Ctp10TokenTransferFromOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *Ctp10TokenTransferFromOperation) SetTokenAmount(AmountValue Amount, Decimals int) error {
+	units, err := AmountValue.RescaledUnits(Decimals)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *Ctp10TokenTransferFromOperation) SetMetadata(Metadata string) {
 	reqData.metadata = Metadata
 }
@@ -1054,6 +1459,14 @@ func (reqData *Ctp10TokenApproveOperation) SetAmount(Amount int64) {
 func (reqData *Ctp10TokenApproveOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *Ctp10TokenApproveOperation) SetTokenAmount(AmountValue Amount, Decimals int) error {
+	units, err := AmountValue.RescaledUnits(Decimals)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *Ctp10TokenApproveOperation) SetMetadata(Metadata string) {
 	reqData.metadata = Metadata
 }
@@ -1101,6 +1514,14 @@ func (reqData *Ctp10TokenAssignOperation) SetAmount(Amount int64) {
 func (reqData *Ctp10TokenAssignOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *Ctp10TokenAssignOperation) SetTokenAmount(AmountValue Amount, Decimals int) error {
+	units, err := AmountValue.RescaledUnits(Decimals)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *Ctp10TokenAssignOperation) SetMetadata(Metadata string) {
 	reqData.metadata = Metadata
 }
@@ -1176,6 +1597,14 @@ func (reqData *ContractCreateOperation) SetInitBalance(InitBalance int64) {
 func (reqData *ContractCreateOperation) GetInitBalance() int64 {
 	return reqData.initBalance
 }
+func (reqData *ContractCreateOperation) SetInitBalanceAmount(InitBalance Amount) error {
+	units, err := InitBalance.RescaledUnits(BU_DECIMALS)
+	if err != nil {
+		return err
+	}
+	reqData.initBalance = units
+	return nil
+}
 func (reqData *ContractCreateOperation) SetPayload(Payload string) {
 	reqData.payload = Payload
 }
@@ -1231,6 +1660,14 @@ func (reqData *ContractInvokeByAssetOperation) SetAmount(Amount int64) {
 func (reqData *ContractInvokeByAssetOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *ContractInvokeByAssetOperation) SetTokenAmount(AmountValue Amount, Decimals int) error {
+	units, err := AmountValue.RescaledUnits(Decimals)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *ContractInvokeByAssetOperation) SetCode(Code string) {
 	reqData.code = Code
 }
@@ -1290,6 +1727,14 @@ func (reqData *ContractInvokeByBUOperation) SetAmount(Amount int64) {
 func (reqData *ContractInvokeByBUOperation) GetAmount() int64 {
 	return reqData.amount
 }
+func (reqData *ContractInvokeByBUOperation) SetBUAmount(AmountValue Amount) error {
+	units, err := AmountValue.RescaledUnits(BU_DECIMALS)
+	if err != nil {
+		return err
+	}
+	reqData.amount = units
+	return nil
+}
 func (reqData *ContractInvokeByBUOperation) SetInput(Input string) {
 	reqData.input = Input
 }
@@ -110,6 +110,56 @@ type AccountGetBalanceResponse struct {
 type AccountGetBalanceResult struct {
 	Balance int64 `json:"balance"`
 }
+type AccountGetSnapshotResponse struct {
+	ErrorCode int             `json:"error_code"`
+	ErrorDesc string          `json:"error_desc"`
+	Result    AccountSnapshot `json:"result"`
+}
+type AccountSnapshot struct {
+	Address   string `json:"address"`
+	LedgerSeq int64  `json:"ledger_seq"`
+	Balance   int64  `json:"balance"`
+	Nonce     int64  `json:"nonce"`
+}
+type AccountActivateBatchResponse struct {
+	ErrorCode int                        `json:"error_code"`
+	ErrorDesc string                     `json:"error_desc"`
+	Result    AccountActivateBatchResult `json:"result"`
+}
+type AccountActivateBatchResult struct {
+	InitBalance int64               `json:"init_balance"`
+	Activations []AccountActivation `json:"activations"`
+}
+type AccountActivation struct {
+	Address   string `json:"address"`
+	Hash      string `json:"hash"`
+	ErrorCode int    `json:"error_code"`
+	ErrorDesc string `json:"error_desc"`
+}
+type AccountGetTransactionHistoryResponse struct {
+	ErrorCode int                                `json:"error_code"`
+	ErrorDesc string                             `json:"error_desc"`
+	Result    AccountGetTransactionHistoryResult `json:"result"`
+}
+type AccountGetTransactionHistoryResult struct {
+	TotalCount int64             `json:"total_count"`
+	Transfers  []AccountTransfer `json:"transfers"`
+}
+type AccountTransfer struct {
+	Address     string `json:"address"`
+	Direction   string `json:"direction"`
+	Type        string `json:"type"`
+	Hash        string `json:"hash"`
+	Index       int64  `json:"index"`
+	LedgerSeq   int64  `json:"ledger_seq"`
+	CloseTime   int64  `json:"close_time"`
+	ErrorCode   int64  `json:"error_code"`
+	Source      string `json:"source"`
+	Dest        string `json:"dest"`
+	Amount      int64  `json:"amount"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+}
 type AccountSetMetadataResponse struct {
 	ErrorCode int                      `json:"error_code"`
 	ErrorDesc string                   `json:"error_desc"`
@@ -224,6 +274,29 @@ type ContractCallResult struct {
 	Stat      Stat                   `json:"stat"`
 	Txs       []Tx                   `json:"txs"`
 }
+type ContractSimulateResponse struct {
+	ErrorCode int                    `json:"error_code"`
+	ErrorDesc string                 `json:"error_desc"`
+	Result    ContractSimulateResult `json:"result"`
+}
+type ContractSimulateResult struct {
+	Logs       map[string]interface{} `json:"logs"`
+	QueryRets  []QueryRet             `json:"query_rets"`
+	Stat       Stat                   `json:"stat"`
+	Txs        []Tx                   `json:"txs"`
+	Exceptions []string               `json:"exceptions"`
+	Overridden bool                   `json:"overridden"`
+}
+type ContractLog struct {
+	Address   string                 `json:"address"`
+	Topic     string                 `json:"topic"`
+	Datas     []string               `json:"datas"`
+	Fields    map[string]interface{} `json:"fields"`
+	Hash      string                 `json:"hash"`
+	Index     int64                  `json:"index"`
+	LedgerSeq int64                  `json:"ledger_seq"`
+	CloseTime int64                  `json:"close_time"`
+}
 type Stat struct {
 	ApplyTime   int64 `json:"apply_time"`
 	MemoryUsage int64 `json:"memory_usage"`
@@ -238,6 +311,17 @@ type ContractGetAddressResponse struct {
 type ContractGetAddressResult struct {
 	ContractAddresInfos []ContractAddresInfo
 }
+type ContractDeployResponse struct {
+	ErrorCode int                  `json:"error_code"`
+	ErrorDesc string               `json:"error_desc"`
+	Result    ContractDeployResult `json:"result"`
+}
+type ContractDeployResult struct {
+	Hash                string               `json:"hash"`
+	GasPrice            int64                `json:"gas_price"`
+	FeeLimit            int64                `json:"fee_limit"`
+	ContractAddresInfos []ContractAddresInfo `json:"contract_address_infos"`
+}
 type ContractAddresInfo struct {
 	ContractAddres string `json:"contract_address"`
 	OperationIndex int    `json:"operation_index"`
@@ -402,7 +486,7 @@ type Operation struct {
 	PayCoin       PayCoin       `json:"pay_coin"`
 	SetMetadata   SetMetadata   `json:"set_metadata"`
 	SetPrivilege  SetPrivilege  `json:"set_privilege"`
-	Log           Log           `json:"set_privilege"`
+	Log           Log           `json:"log"`
 }
 type CreateAccount struct {
 	DestAddress string        `json:"dest_address"`
@@ -452,10 +536,28 @@ type BlockGetInfoResult struct {
 	Header GetInfoHeader `json:"header"`
 }
 type GetInfoHeader struct {
-	CloseTime int64 `json:"close_time"`
-	Number    int64 `json:"seq"`
-	TxCount   int64 `json:"tx_count"`
-	Version   int64 `json:"version"`
+	CloseTime          int64  `json:"close_time"`
+	Number             int64  `json:"seq"`
+	TxCount            int64  `json:"tx_count"`
+	Version            int64  `json:"version"`
+	Hash               string `json:"hash"`
+	PreviousHash       string `json:"previous_hash"`
+	AccountTreeHash    string `json:"account_tree_hash"`
+	ConsensusValueHash string `json:"consensus_value_hash"`
+	ValidatorsHash     string `json:"validators_hash"`
+	FeesHash           string `json:"fees_hash"`
+}
+
+//VerifyTransactions
+type BlockVerifyTransactionsResponse struct {
+	ErrorCode int                      `json:"error_code"`
+	ErrorDesc string                   `json:"error_desc"`
+	Result    VerifyTransactionsResult `json:"result"`
+}
+type VerifyTransactionsResult struct {
+	IsValid             bool   `json:"is_valid"`
+	TxCount             int64  `json:"tx_count"`
+	TransactionTreeHash string `json:"transaction_tree_hash"`
 }
 
 //GetLatest
@@ -468,10 +570,16 @@ type GetLatestResult struct {
 	Header GetLatestHeader `json:"header"`
 }
 type GetLatestHeader struct {
-	CloseTime int64 `json:"close_time"`
-	Number    int64 `json:"seq"`
-	TxCount   int64 `json:"tx_count"`
-	Version   int64 `json:"version"`
+	CloseTime          int64  `json:"close_time"`
+	Number             int64  `json:"seq"`
+	TxCount            int64  `json:"tx_count"`
+	Version            int64  `json:"version"`
+	Hash               string `json:"hash"`
+	PreviousHash       string `json:"previous_hash"`
+	AccountTreeHash    string `json:"account_tree_hash"`
+	ConsensusValueHash string `json:"consensus_value_hash"`
+	ValidatorsHash     string `json:"validators_hash"`
+	FeesHash           string `json:"fees_hash"`
 }
 
 //GetNumber
@@ -569,6 +677,61 @@ type ValidatorReward struct {
 	Reward    int64
 }
 
+//GetValidatorPledges
+type BlockGetValidatorPledgesResponse struct {
+	ErrorCode int                            `json:"error_code"`
+	ErrorDesc string                         `json:"error_desc"`
+	Result    BlockGetValidatorPledgesResult `json:"result"`
+}
+type BlockGetValidatorPledgesResult struct {
+	LedgerSeq   int64             `json:"ledger_seq"`
+	TotalPledge int64             `json:"total_pledge"`
+	Validators  []ValidatorPledge `json:"validators"`
+}
+type ValidatorPledge struct {
+	Address          string  `json:"address"`
+	PledgeCoinAmount int64   `json:"pledge_coin_amount"`
+	Share            float64 `json:"share"`
+}
+type ValidatorChange struct {
+	LedgerSeq int64       `json:"ledger_seq"`
+	Added     []Validator `json:"added"`
+	Removed   []Validator `json:"removed"`
+	Changed   []Validator `json:"changed"`
+}
+
+//GetRewardSample
+type BlockGetRewardSampleResponse struct {
+	ErrorCode int          `json:"error_code"`
+	ErrorDesc string       `json:"error_desc"`
+	Result    RewardSample `json:"result"`
+}
+type RewardSample struct {
+	LedgerSeq  int64           `json:"ledger_seq"`
+	Validators []AddressReward `json:"validators"`
+	Kols       []AddressReward `json:"kols"`
+}
+type AddressReward struct {
+	Address string `json:"address"`
+	Reward  int64  `json:"reward"`
+}
+type RewardReport struct {
+	StartSeq         int64         `json:"start_seq"`
+	EndSeq           int64         `json:"end_seq"`
+	Samples          int           `json:"samples"`
+	ValidatorTotal   int64         `json:"validator_total"`
+	ValidatorAverage float64       `json:"validator_average"`
+	KolTotal         int64         `json:"kol_total"`
+	KolAverage       float64       `json:"kol_average"`
+	Validators       []RewardTotal `json:"validators"`
+	Kols             []RewardTotal `json:"kols"`
+}
+type RewardTotal struct {
+	Address          string  `json:"address"`
+	Reward           int64   `json:"reward"`
+	AveragePerLedger float64 `json:"average_per_ledger"`
+}
+
 type BlockGetFeesResponse struct {
 	ErrorCode int           `json:"error_code"`
 	ErrorDesc string        `json:"error_desc"`
@@ -582,6 +745,13 @@ type Fees struct {
 	GasPrice    int64 `json:"gas_price"`
 }
 
+//GetChainParams
+type BlockGetChainParamsResponse struct {
+	ErrorCode int         `json:"error_code"`
+	ErrorDesc string      `json:"error_desc"`
+	Result    ChainParams `json:"result"`
+}
+
 //GetLatestFees
 type BlockGetLatestFeesResponse struct {
 	ErrorCode int                 `json:"error_code"`
@@ -762,6 +932,58 @@ type Ctp10TokenGetInfoResult struct {
 	TotalSupply int64  `json:"totalSupply"`
 	Name        string `json:"name"`
 }
+type Ctp10TokenInfo struct {
+	Ctp         string `json:"ctp"`
+	Name        string `json:"name"`
+	Symbol      string `json:"symbol"`
+	Decimals    int    `json:"decimals"`
+	TotalSupply Amount `json:"totalSupply"`
+	Owner       string `json:"contractOwner"`
+	// the part of the supply the owner has not assigned yet
+	Unassigned Amount `json:"balance"`
+}
+type Atp10Metadata struct {
+	Version     string `json:"version"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Decimals    int64  `json:"decimals"`
+	// in base units, 0 for an unlimited supply
+	TotalSupply int64  `json:"totalSupply"`
+	Icon        string `json:"icon"`
+}
+type Atp10Info struct {
+	Issuer      string        `json:"issuer"`
+	MetadataKey string        `json:"metadata_key"`
+	Metadata    Atp10Metadata `json:"metadata"`
+	// the amount issued so far in base units, and whether it is the record kept
+	// by the SDK or only the balance of the issuer
+	Issued         int64 `json:"issued"`
+	IssuedRecorded bool  `json:"issued_recorded"`
+	IssuedVersion  int64 `json:"issued_version"`
+}
+// PortfolioHolding is the balance of one asset or token of a portfolio.
+// ErrorCode and ErrorDesc tell why it could not be read; Balance is then in
+// base units, or zero when even those are unknown.
+type PortfolioHolding struct {
+	// "ATP10" for assets with ATP 1.0 metadata, "" for other assets, or "CTP10"
+	Standard        string `json:"standard"`
+	Issuer          string `json:"issuer,omitempty"`
+	Code            string `json:"code,omitempty"`
+	ContractAddress string `json:"contract_address,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Symbol          string `json:"symbol,omitempty"`
+	Decimals        int    `json:"decimals"`
+	Balance         Amount `json:"balance"`
+	ErrorCode       int    `json:"error_code"`
+	ErrorDesc       string `json:"error_desc"`
+}
+type Portfolio struct {
+	Address string             `json:"address"`
+	BU      Amount             `json:"bu"`
+	Assets  []PortfolioHolding `json:"assets"`
+	Tokens  []PortfolioHolding `json:"tokens"`
+}
 type CallGetNameResponse struct {
 	ErrorCode int               `json:"error_code"`
 	ErrorDesc string            `json:"error_desc"`
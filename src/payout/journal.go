@@ -0,0 +1,119 @@
+// journal
+package payout
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// Batch states
+const (
+	// signed and saved before it is submitted; it may or may not be on the node
+	BATCH_SIGNED = "signed"
+	// in a ledger and successful
+	BATCH_CONFIRMED = "confirmed"
+	// in a ledger but failed, so none of its payments were made; they are
+	// paid again
+	BATCH_FAILED = "failed"
+	// refused by the node or outdated by its nonce; it can never be applied
+	BATCH_REJECTED = "rejected"
+)
+
+// PayoutBatch is one transaction of a payout. The signed blob is saved before
+// it is submitted, so after a crash the same transaction is submitted again:
+// its nonce lets the chain apply it at most once.
+type PayoutBatch struct {
+	ID         int64             `json:"id"`
+	Nonce      int64             `json:"nonce"`
+	Hash       string            `json:"hash"`
+	Blob       string            `json:"blob"`
+	Signatures []model.Signature `json:"signatures"`
+	Recipients []string          `json:"recipients"`
+	State      string            `json:"state"`
+	LedgerSeq  int64             `json:"ledger_seq,omitempty"`
+	ErrorCode  int64             `json:"error_code,omitempty"`
+	ErrorDesc  string            `json:"error_desc,omitempty"`
+}
+
+// PayoutJournal keeps the batches of a payout. Save must be durable when it
+// returns; a batch saved again replaces the batch with the same ID.
+type PayoutJournal interface {
+	Load() ([]PayoutBatch, error)
+	Save(batch PayoutBatch) error
+}
+
+// FileJournal keeps the journal in a JSON Lines file, appending one line each
+// time a batch is saved. A line cut short by a crash is skipped.
+type FileJournal struct {
+	Path string
+}
+
+// Load returns the batches in the order of their IDs.
+func (journal *FileJournal) Load() ([]PayoutBatch, error) {
+	file, err := os.Open(journal.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var batches []PayoutBatch
+	index := make(map[int64]int)
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// a last line without its newline was not completely written
+			break
+		}
+		var batch PayoutBatch
+		if json.Unmarshal(line, &batch) != nil {
+			continue
+		}
+		i, ok := index[batch.ID]
+		if ok {
+			batches[i] = batch
+			continue
+		}
+		index[batch.ID] = len(batches)
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// Save
+func (journal *FileJournal) Save(batch PayoutBatch) error {
+	line, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(journal.Path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	// end a line cut short by a crash, so it does not run into this one
+	info, err := file.Stat()
+	if err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		_, err = file.ReadAt(last, info.Size()-1)
+		if err == nil && last[0] != '\n' {
+			line = append([]byte{'\n'}, line...)
+		}
+	}
+	if err == nil {
+		_, err = file.Write(line)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	return err
+}
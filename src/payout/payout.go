@@ -0,0 +1,543 @@
+// payout
+package payout
+
+import (
+	"container/list"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/common"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/merkle"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/bumoproject/bumo-sdk-go/src/token"
+)
+
+// Recipient statuses
+const (
+	// not paid yet, or its transaction was rejected or failed
+	STATUS_PENDING = "pending"
+	// in a transaction that is not confirmed yet
+	STATUS_SUBMITTED = "submitted"
+	STATUS_PAID      = "paid"
+	// its payment could not be built
+	STATUS_FAILED = "failed"
+)
+
+const (
+	defaultPayoutTimeout = 60 * time.Second
+	// how often Run asks the node whether the transactions are confirmed
+	payoutPollInterval = time.Second
+)
+
+// PayoutStatus is the status of one recipient after Run. Hash is the
+// transaction paying it, once there is one. A pending recipient whose last
+// transaction failed on chain keeps its hash and error.
+type PayoutStatus struct {
+	Recipient PayoutRecipient
+	Status    string
+	Hash      string
+	ErrorCode int
+	ErrorDesc string
+}
+
+// PayoutEngine pays many recipients from one account. It packs the payments
+// into as few transactions as MaxOperations and MaxSize allow, numbers them
+// from the nonce of the account, and records every signed transaction in the
+// journal before submitting it. Running it again with the same recipients and
+// journal completes an interrupted payout: transactions in the journal are
+// looked up and submitted again when the node does not know them, and only
+// recipients no transaction pays are paid.
+//
+// A transaction that failed on chain applied none of its payments, so its
+// recipients are pending again and the next run pays them in a new
+// transaction. A BU payment to an account that does not exist yet must be
+// at least the base reserve, which creates the account; smaller ones fail
+// before they are packed.
+type PayoutEngine struct {
+	Url           string
+	Network       model.Network
+	SourceAddress string
+	PrivateKeys   []string
+	Journal       PayoutJournal
+	// limits of one transaction, blockchain.MAX_TRANSACTION_OPERATIONS and
+	// blockchain.DEFAULT_MAX_TRANSACTION_SIZE when 0
+	MaxOperations int
+	MaxSize       int
+	// the gas price and fee limit of every transaction; the fees of each
+	// transaction are estimated when they are 0
+	GasPrice int64
+	FeeLimit int64
+	Metadata string
+	// the least time between two submissions
+	SubmitInterval time.Duration
+	// how long Run waits for the transactions to be confirmed, 60 seconds by
+	// default
+	Timeout time.Duration
+
+	estimator  *blockchain.FeeEstimator
+	lastSubmit time.Time
+	decimals   map[model.Key]int
+	tokens     map[string]*token.Ctp10Token
+	// the base reserve of the chain, read once, and the accounts known to
+	// exist or created by an earlier payment
+	baseReserve int64
+	activated   map[string]bool
+}
+
+// Run pays the recipients that are not paid yet and waits for the
+// transactions. The statuses are returned in the order of the recipients,
+// also when Run fails part way: a rejected transaction stops the payout, and
+// transactions still unconfirmed after the timeout give
+// TRANSACTION_TIMEOUT_ERROR.
+func (engine *PayoutEngine) Run(recipients []PayoutRecipient) ([]PayoutStatus, exception.SDKResponse) {
+	SDKRes := engine.check(recipients)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	batches, err := engine.Journal.Load()
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.PAYOUT_JOURNAL_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return nil, SDKRes
+	}
+	// accounts may have been created since the last run
+	engine.activated = nil
+	// settle the transactions of an earlier run first
+	nonce, SDKRes := engine.resolve(batches, true)
+	if SDKRes.ErrorCode != 0 {
+		return engine.statuses(recipients, batches, nil), SDKRes
+	}
+
+	failures := make(map[string]exception.SDKResponse)
+	statuses := engine.statuses(recipients, batches, failures)
+	var operations []model.BaseOperation
+	var sizes []int
+	var ids []string
+	for _, status := range statuses {
+		if status.Status != STATUS_PENDING {
+			continue
+		}
+		operation, size, SDKRes := engine.operation(status.Recipient)
+		if SDKRes.ErrorCode != 0 {
+			failures[status.Recipient.ID] = SDKRes
+			continue
+		}
+		operations = append(operations, operation)
+		sizes = append(sizes, size)
+		ids = append(ids, status.Recipient.ID)
+	}
+
+	for _, batch := range batches {
+		if batch.State == BATCH_SIGNED && batch.Nonce > nonce {
+			nonce = batch.Nonce
+		}
+	}
+	var id int64
+	if len(batches) != 0 {
+		id = batches[len(batches)-1].ID + 1
+	}
+	envelope := blockchain.EnvelopeSize(engine.SourceAddress, engine.Metadata, len(engine.PrivateKeys))
+	for _, end := range blockchain.PackOperations(sizes, envelope, engine.MaxOperations, engine.MaxSize) {
+		nonce++
+		batch, SDKRes := engine.submit(id, nonce, operations[:end], ids[:end])
+		if batch.Blob != "" {
+			batches = append(batches, batch)
+		}
+		if SDKRes.ErrorCode != 0 {
+			return engine.statuses(recipients, batches, failures), SDKRes
+		}
+		operations, ids = operations[end:], ids[end:]
+		id++
+	}
+
+	SDKRes = engine.wait(batches)
+	return engine.statuses(recipients, batches, failures), SDKRes
+}
+
+func (engine *PayoutEngine) check(recipients []PayoutRecipient) exception.SDKResponse {
+	if !engine.Network.CheckAddress(engine.SourceAddress) {
+		return exception.GetSDKRes(exception.INVALID_SOURCEADDRESS_ERROR)
+	}
+	if len(engine.PrivateKeys) == 0 {
+		return exception.GetSDKRes(exception.PRIVATEKEY_NULL_ERROR)
+	}
+	if engine.Journal == nil {
+		SDKRes := exception.GetSDKRes(exception.PAYOUT_JOURNAL_ERROR)
+		SDKRes.ErrorDesc += ": journal is nil"
+		return SDKRes
+	}
+	return checkRecipients(recipients, engine.Network)
+}
+
+// statuses tells the status of every recipient from the last batch paying it
+// that was neither rejected nor failed, and from the failures to build its
+// payment. Recipients of failed batches only are pending, with the error of
+// the last one.
+func (engine *PayoutEngine) statuses(recipients []PayoutRecipient, batches []PayoutBatch, failures map[string]exception.SDKResponse) []PayoutStatus {
+	paying := make(map[string]*PayoutBatch)
+	failed := make(map[string]*PayoutBatch)
+	for i := range batches {
+		switch batches[i].State {
+		case BATCH_REJECTED:
+			continue
+		case BATCH_FAILED:
+			for _, id := range batches[i].Recipients {
+				failed[id] = &batches[i]
+			}
+			continue
+		}
+		for _, id := range batches[i].Recipients {
+			paying[id] = &batches[i]
+		}
+	}
+	statuses := make([]PayoutStatus, len(recipients))
+	for i, recipient := range recipients {
+		status := PayoutStatus{Recipient: recipient, Status: STATUS_PENDING}
+		if batch, ok := paying[recipient.ID]; ok {
+			status.Hash = batch.Hash
+			switch batch.State {
+			case BATCH_SIGNED:
+				status.Status = STATUS_SUBMITTED
+			case BATCH_CONFIRMED:
+				status.Status = STATUS_PAID
+			}
+		} else if SDKRes, ok := failures[recipient.ID]; ok {
+			status.Status = STATUS_FAILED
+			status.ErrorCode = SDKRes.ErrorCode
+			status.ErrorDesc = SDKRes.ErrorDesc
+		} else if batch, ok := failed[recipient.ID]; ok {
+			status.Hash = batch.Hash
+			status.ErrorCode = int(batch.ErrorCode)
+			status.ErrorDesc = batch.ErrorDesc
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// operation builds the payment of a recipient and tells its serialized size.
+func (engine *PayoutEngine) operation(recipient PayoutRecipient) (model.BaseOperation, int, exception.SDKResponse) {
+	var operation model.BaseOperation
+	switch {
+	case recipient.ContractAddress != "":
+		ctp10, SDKRes := engine.token(recipient.ContractAddress)
+		if SDKRes.ErrorCode != 0 {
+			return nil, 0, SDKRes
+		}
+		amount, err := ctp10.ParseAmount(recipient.Amount)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.INVALID_TOKEN_AMOUNT_ERROR)
+			SDKRes.ErrorDesc += ": " + err.Error()
+			return nil, 0, SDKRes
+		}
+		transfer, SDKRes := ctp10.Transfer(engine.SourceAddress, recipient.Address, amount)
+		if SDKRes.ErrorCode != 0 {
+			return nil, 0, SDKRes
+		}
+		operation = transfer
+	case recipient.Code != "":
+		decimals, SDKRes := engine.assetDecimals(recipient.Issuer, recipient.Code)
+		if SDKRes.ErrorCode != 0 {
+			return nil, 0, SDKRes
+		}
+		amount, err := model.ParseAmount(recipient.Amount, decimals)
+		if err == nil && amount.Sign() <= 0 {
+			err = model.ErrInvalidAmount
+		}
+		var send model.AssetSendOperation
+		send.Init()
+		if err == nil {
+			err = send.SetTokenAmount(amount, decimals)
+		}
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.INVALID_ASSET_AMOUNT_ERROR)
+			SDKRes.ErrorDesc += ": " + err.Error()
+			return nil, 0, SDKRes
+		}
+		send.SetDestAddress(recipient.Address)
+		send.SetCode(recipient.Code)
+		send.SetIssuer(recipient.Issuer)
+		operation = send
+	default:
+		amount, err := model.ParseBU(recipient.Amount)
+		if err == nil && amount.Sign() <= 0 {
+			err = model.ErrInvalidAmount
+		}
+		var send model.BUSendOperation
+		send.Init()
+		if err == nil {
+			err = send.SetBUAmount(amount)
+		}
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.INVALID_BU_AMOUNT_ERROR)
+			SDKRes.ErrorDesc += ": " + err.Error()
+			return nil, 0, SDKRes
+		}
+		SDKRes := engine.checkReserve(recipient.Address, send.GetAmount())
+		if SDKRes.ErrorCode != 0 {
+			return nil, 0, SDKRes
+		}
+		send.SetDestAddress(recipient.Address)
+		operation = send
+	}
+	var operationList list.List
+	operationList.PushBack(operation)
+	operations, SDKRes := common.GetOperations(operationList, engine.Url, engine.SourceAddress, engine.Network)
+	if SDKRes.ErrorCode != 0 {
+		return nil, 0, SDKRes
+	}
+	return operation, blockchain.OperationSize(operations[0]), SDKRes
+}
+
+// checkReserve checks that a BU payment to an account that does not exist yet
+// is at least the base reserve, and then counts the account as created.
+func (engine *PayoutEngine) checkReserve(address string, amount int64) exception.SDKResponse {
+	if engine.activated[address] {
+		return exception.GetSDKRes(exception.SUCCESS)
+	}
+	activated, SDKRes := common.CheckActivated(address, engine.Url, engine.Network)
+	if SDKRes.ErrorCode != 0 {
+		return SDKRes
+	}
+	if !activated {
+		if engine.baseReserve == 0 {
+			_, engine.baseReserve, SDKRes = common.GetLatestFees(engine.Url)
+			if SDKRes.ErrorCode != 0 {
+				return SDKRes
+			}
+		}
+		if amount < engine.baseReserve {
+			SDKRes := exception.GetSDKRes(exception.INVALID_BU_AMOUNT_ERROR)
+			SDKRes.ErrorDesc += ": the account does not exist and the base reserve is " + strconv.FormatInt(engine.baseReserve, 10)
+			return SDKRes
+		}
+	}
+	if engine.activated == nil {
+		engine.activated = make(map[string]bool)
+	}
+	engine.activated[address] = true
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+// assetDecimals reads the decimals of an asset from its ATP 1.0 metadata, 0
+// for assets without it, once per asset.
+func (engine *PayoutEngine) assetDecimals(issuer string, code string) (int, exception.SDKResponse) {
+	key := model.Key{Code: code, Issuer: issuer}
+	if decimals, ok := engine.decimals[key]; ok {
+		return decimals, exception.GetSDKRes(exception.SUCCESS)
+	}
+	atp10 := token.Atp10Operation{Url: engine.Url, Network: engine.Network}
+	info, SDKRes := atp10.GetInfo(issuer, code)
+	if SDKRes.ErrorCode != 0 && SDKRes.ErrorCode != exception.ATP10_ASSET_NOT_FOUND_ERROR {
+		return 0, SDKRes
+	}
+	if engine.decimals == nil {
+		engine.decimals = make(map[model.Key]int)
+	}
+	engine.decimals[key] = int(info.Metadata.Decimals)
+	return engine.decimals[key], exception.GetSDKRes(exception.SUCCESS)
+}
+
+// token returns the handle of a CTP10 token, checking the contract once.
+func (engine *PayoutEngine) token(contractAddress string) (*token.Ctp10Token, exception.SDKResponse) {
+	if ctp10, ok := engine.tokens[contractAddress]; ok {
+		return ctp10, exception.GetSDKRes(exception.SUCCESS)
+	}
+	tokenOperation := token.Ctp10TokenOperation{Url: engine.Url, Network: engine.Network}
+	ctp10, SDKRes := tokenOperation.Token(contractAddress)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	if engine.tokens == nil {
+		engine.tokens = make(map[string]*token.Ctp10Token)
+	}
+	engine.tokens[contractAddress] = ctp10
+	return ctp10, SDKRes
+}
+
+// submit signs a transaction of the operations, saves it in the journal and
+// submits it. The batch has no blob when it was not signed.
+func (engine *PayoutEngine) submit(id int64, nonce int64, operations []model.BaseOperation, ids []string) (PayoutBatch, exception.SDKResponse) {
+	batch := PayoutBatch{ID: id, Nonce: nonce, Recipients: append([]string{}, ids...), State: BATCH_SIGNED}
+	gasPrice, feeLimit := engine.GasPrice, engine.FeeLimit
+	if gasPrice == 0 || feeLimit == 0 {
+		if engine.estimator == nil {
+			engine.estimator = &blockchain.FeeEstimator{Url: engine.Url, Network: engine.Network}
+		}
+		var reqDataFee model.TransactionEvaluateFeeRequest
+		reqDataFee.SetSourceAddress(engine.SourceAddress)
+		reqDataFee.SetNonce(nonce)
+		reqDataFee.SetSignatureNumber(strconv.Itoa(len(engine.PrivateKeys)))
+		reqDataFee.SetMetadata(engine.Metadata)
+		for _, operation := range operations {
+			reqDataFee.AddOperation(operation)
+		}
+		resDataFee := engine.estimator.EstimateFee(reqDataFee)
+		if resDataFee.ErrorCode != 0 {
+			return batch, exception.SDKResponse{ErrorCode: resDataFee.ErrorCode, ErrorDesc: resDataFee.ErrorDesc}
+		}
+		if gasPrice == 0 {
+			gasPrice = resDataFee.Result.GasPrice
+		}
+		if feeLimit == 0 {
+			feeLimit = resDataFee.Result.FeeLimit
+		}
+	}
+	transaction := blockchain.TransactionOperation{Url: engine.Url, Network: engine.Network}
+	var reqDataBlob model.TransactionBuildBlobRequest
+	reqDataBlob.SetSourceAddress(engine.SourceAddress)
+	reqDataBlob.SetNonce(nonce)
+	reqDataBlob.SetGasPrice(gasPrice)
+	reqDataBlob.SetFeeLimit(feeLimit)
+	reqDataBlob.SetMetadata(engine.Metadata)
+	for _, operation := range operations {
+		reqDataBlob.AddOperation(operation)
+	}
+	resDataBlob := transaction.BuildBlob(reqDataBlob)
+	if resDataBlob.ErrorCode != 0 {
+		return batch, exception.SDKResponse{ErrorCode: resDataBlob.ErrorCode, ErrorDesc: resDataBlob.ErrorDesc}
+	}
+	var reqDataSign model.TransactionSignRequest
+	reqDataSign.SetBlob(resDataBlob.Result.Blob)
+	reqDataSign.SetPrivateKeys(engine.PrivateKeys)
+	resDataSign := transaction.Sign(reqDataSign)
+	if resDataSign.ErrorCode != 0 {
+		return batch, exception.SDKResponse{ErrorCode: resDataSign.ErrorCode, ErrorDesc: resDataSign.ErrorDesc}
+	}
+	data, err := hex.DecodeString(resDataBlob.Result.Blob)
+	if err != nil {
+		return batch, exception.GetSDKRes(exception.INVALID_BLOB_ERROR)
+	}
+	batch.Hash = hex.EncodeToString(merkle.Hash(data))
+	batch.Blob = resDataBlob.Result.Blob
+	batch.Signatures = resDataSign.Result.Signatures
+	err = engine.Journal.Save(batch)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.PAYOUT_JOURNAL_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		// not submitted, so the batch does not count
+		return PayoutBatch{}, SDKRes
+	}
+
+	SDKRes := engine.send(batch)
+	if SDKRes.ErrorCode == 0 || SDKRes.ErrorCode == exception.CONNECTNETWORK_ERROR || SDKRes.ErrorCode == exception.SYSTEM_ERROR {
+		// after a network failure the node may still have it
+		return batch, SDKRes
+	}
+	// the node refused the transaction, so its nonce is still free
+	batch.State = BATCH_REJECTED
+	batch.ErrorCode = int64(SDKRes.ErrorCode)
+	batch.ErrorDesc = SDKRes.ErrorDesc
+	err = engine.Journal.Save(batch)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.PAYOUT_JOURNAL_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return batch, SDKRes
+	}
+	return batch, SDKRes
+}
+
+// send submits a signed batch, no sooner than SubmitInterval after the last
+// submission.
+func (engine *PayoutEngine) send(batch PayoutBatch) exception.SDKResponse {
+	if wait := engine.lastSubmit.Add(engine.SubmitInterval).Sub(time.Now()); wait > 0 {
+		time.Sleep(wait)
+	}
+	engine.lastSubmit = time.Now()
+	transaction := blockchain.TransactionOperation{Url: engine.Url, Network: engine.Network}
+	var reqDataSubmit model.TransactionSubmitRequest
+	reqDataSubmit.SetBlob(batch.Blob)
+	reqDataSubmit.SetSignatures(batch.Signatures)
+	resDataSubmit := transaction.Submit(reqDataSubmit)
+	return exception.SDKResponse{ErrorCode: resDataSubmit.ErrorCode, ErrorDesc: resDataSubmit.ErrorDesc}
+}
+
+// resolve looks up the signed batches and saves those that are settled:
+// confirmed or failed when they are in a ledger, rejected when the account
+// has used their nonce without them. With resubmit set, the others are
+// submitted again. It returns the nonce of the account.
+func (engine *PayoutEngine) resolve(batches []PayoutBatch, resubmit bool) (int64, exception.SDKResponse) {
+	// the nonce is read first: a transaction not found after the nonce moved
+	// past it can no longer be applied
+	Account := account.AccountOperation{Url: engine.Url, Network: engine.Network}
+	var reqDataNonce model.AccountGetNonceRequest
+	reqDataNonce.SetAddress(engine.SourceAddress)
+	resDataNonce := Account.GetNonce(reqDataNonce)
+	if resDataNonce.ErrorCode != 0 {
+		return 0, exception.SDKResponse{ErrorCode: resDataNonce.ErrorCode, ErrorDesc: resDataNonce.ErrorDesc}
+	}
+	nonce := resDataNonce.Result.Nonce
+	transaction := blockchain.TransactionOperation{Url: engine.Url, Network: engine.Network}
+	for i := range batches {
+		batch := &batches[i]
+		if batch.State != BATCH_SIGNED {
+			continue
+		}
+		var reqDataInfo model.TransactionGetInfoRequest
+		reqDataInfo.SetHash(batch.Hash)
+		resDataInfo := transaction.GetInfo(reqDataInfo)
+		switch {
+		case resDataInfo.ErrorCode == 0 && len(resDataInfo.Result.Transactions) != 0:
+			info := resDataInfo.Result.Transactions[0]
+			batch.LedgerSeq = info.LedgerSeq
+			batch.State = BATCH_CONFIRMED
+			if info.ErrorCode != 0 {
+				batch.State = BATCH_FAILED
+				batch.ErrorCode = info.ErrorCode
+				batch.ErrorDesc = info.ErrorDesc
+			}
+		case resDataInfo.ErrorCode != 0 && resDataInfo.ErrorCode != 4:
+			return nonce, exception.SDKResponse{ErrorCode: resDataInfo.ErrorCode, ErrorDesc: resDataInfo.ErrorDesc}
+		case nonce >= batch.Nonce:
+			batch.State = BATCH_REJECTED
+			batch.ErrorDesc = "The nonce was used by another transaction"
+		default:
+			if resubmit {
+				// the node refuses a transaction it already has, so only
+				// network failures count
+				SDKRes := engine.send(*batch)
+				if SDKRes.ErrorCode == exception.CONNECTNETWORK_ERROR || SDKRes.ErrorCode == exception.SYSTEM_ERROR {
+					return nonce, SDKRes
+				}
+			}
+			continue
+		}
+		err := engine.Journal.Save(*batch)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.PAYOUT_JOURNAL_ERROR)
+			SDKRes.ErrorDesc += ": " + err.Error()
+			return nonce, SDKRes
+		}
+	}
+	return nonce, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// wait resolves the signed batches until none is left or the timeout passes.
+func (engine *PayoutEngine) wait(batches []PayoutBatch) exception.SDKResponse {
+	timeout := engine.Timeout
+	if timeout <= 0 {
+		timeout = defaultPayoutTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		_, SDKRes := engine.resolve(batches, false)
+		if SDKRes.ErrorCode != 0 {
+			return SDKRes
+		}
+		signed := false
+		for _, batch := range batches {
+			signed = signed || batch.State == BATCH_SIGNED
+		}
+		if !signed {
+			return exception.GetSDKRes(exception.SUCCESS)
+		}
+		if time.Now().Add(payoutPollInterval).After(deadline) {
+			return exception.GetSDKRes(exception.TRANSACTION_TIMEOUT_ERROR)
+		}
+		time.Sleep(payoutPollInterval)
+	}
+}
@@ -0,0 +1,312 @@
+// payout_test
+package payout_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/bumoproject/bumo-sdk-go/src/payout"
+	"github.com/golang/protobuf/proto"
+)
+
+// a node applying every transaction of the source as soon as it is submitted
+type payoutNode struct {
+	lock     sync.Mutex
+	source   string
+	nonce    int64
+	applied  map[string]bool
+	payments map[string]int64
+	failed   map[string]bool
+	submits  int
+	// down fails submissions as a network failure, reject as a refusal, and
+	// fail applies transactions as failed
+	down   bool
+	reject bool
+	fail   bool
+}
+
+func (node *payoutNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	node.lock.Lock()
+	defer node.lock.Unlock()
+	switch r.URL.Path {
+	case "/getAccount":
+		address := r.URL.Query().Get("address")
+		if _, ok := node.payments[address]; !ok && address != node.source {
+			w.Write([]byte(`{"error_code":4}`))
+			return
+		}
+		w.Write([]byte(`{"error_code":0,"result":{"address":"` + address + `","nonce":` + strconv.FormatInt(node.nonce, 10) + `}}`))
+	case "/getLedger":
+		w.Write([]byte(`{"error_code":0,"result":{"fees":{"gas_price":1000,"base_reserve":10000000}}}`))
+	case "/getTransactionHistory":
+		hash := r.URL.Query().Get("hash")
+		if !node.applied[hash] {
+			w.Write([]byte(`{"error_code":4}`))
+			return
+		}
+		errorCode := "0"
+		if node.failed[hash] {
+			errorCode = "100"
+		}
+		w.Write([]byte(`{"error_code":0,"result":{"total_count":1,"transactions":[{"error_code":` + errorCode + `,"hash":"` + hash + `","ledger_seq":10}]}}`))
+	case "/submitTransaction":
+		if node.down {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		node.submits++
+		var request struct {
+			Items []struct {
+				Blob string `json:"transaction_blob"`
+			} `json:"items"`
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &request)
+		blob, _ := hex.DecodeString(request.Items[0].Blob)
+		sum := sha256.Sum256(blob)
+		hash := hex.EncodeToString(sum[:])
+		var transaction protocol.Transaction
+		proto.Unmarshal(blob, &transaction)
+		if node.reject || node.applied[hash] || transaction.Nonce != node.nonce+1 {
+			w.Write([]byte(`{"results":[{"error_code":93,"error_desc":"refused","hash":"` + hash + `"}],"success_count":0}`))
+			return
+		}
+		node.nonce++
+		node.applied[hash] = true
+		if node.fail {
+			node.failed[hash] = true
+			w.Write([]byte(`{"results":[{"error_code":0,"hash":"` + hash + `"}],"success_count":1}`))
+			return
+		}
+		for _, operation := range transaction.Operations {
+			node.payments[operation.GetPayCoin().GetDestAddress()] += operation.GetPayCoin().GetAmount()
+		}
+		w.Write([]byte(`{"results":[{"error_code":0,"hash":"` + hash + `"}],"success_count":1}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newPayout(t *testing.T, count int) (*payoutNode, *payout.PayoutEngine, []payout.PayoutRecipient, func()) {
+	_, privateKey, source, err := keypair.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := &payoutNode{source: source, nonce: 7, applied: make(map[string]bool), payments: make(map[string]int64), failed: make(map[string]bool)}
+	server := httptest.NewServer(node)
+	dir, err := ioutil.TempDir("", "payout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := &payout.PayoutEngine{
+		Url:           server.URL,
+		SourceAddress: source,
+		PrivateKeys:   []string{privateKey},
+		Journal:       &payout.FileJournal{Path: filepath.Join(dir, "journal")},
+		MaxOperations: 2,
+		GasPrice:      1000,
+		FeeLimit:      1000000,
+	}
+	var recipients []payout.PayoutRecipient
+	for i := 0; i < count; i++ {
+		_, _, address, _ := keypair.Create()
+		recipients = append(recipients, payout.PayoutRecipient{ID: strconv.Itoa(i), Address: address, Amount: "1.5"})
+	}
+	return node, engine, recipients, func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// every recipient is paid exactly 1.5 BU
+func checkPaid(t *testing.T, node *payoutNode, statuses []payout.PayoutStatus) {
+	for _, status := range statuses {
+		if status.Status != payout.STATUS_PAID || status.Hash == "" {
+			t.Errorf("wrong status %+v", status)
+		}
+		if paid := node.payments[status.Recipient.Address]; paid != 150000000 {
+			t.Errorf("%s is paid %d", status.Recipient.ID, paid)
+		}
+	}
+}
+
+func Test_PayoutRun(t *testing.T) {
+	node, engine, recipients, done := newPayout(t, 5)
+	defer done()
+	statuses, SDKRes := engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if node.submits != 3 || node.nonce != 10 {
+		t.Errorf("%d submissions up to nonce %d", node.submits, node.nonce)
+	}
+	checkPaid(t, node, statuses)
+
+	// a second run pays nobody again
+	statuses, SDKRes = engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 || node.submits != 3 {
+		t.Fatal("paid again:", SDKRes.ErrorDesc)
+	}
+	checkPaid(t, node, statuses)
+}
+
+func Test_PayoutResume(t *testing.T) {
+	node, engine, recipients, done := newPayout(t, 5)
+	defer done()
+	// the first transaction is signed but its submission is lost
+	node.down = true
+	statuses, SDKRes := engine.Run(recipients)
+	if SDKRes.ErrorCode != 11007 {
+		t.Fatal("submitted while the node is down:", SDKRes.ErrorDesc)
+	}
+	if statuses[0].Status != payout.STATUS_SUBMITTED || statuses[2].Status != payout.STATUS_PENDING {
+		t.Errorf("wrong statuses %+v", statuses)
+	}
+
+	// the same transaction is submitted again, then the others
+	node.down = false
+	statuses, SDKRes = engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if node.submits != 3 || node.nonce != 10 {
+		t.Errorf("%d submissions up to nonce %d", node.submits, node.nonce)
+	}
+	checkPaid(t, node, statuses)
+}
+
+func Test_PayoutRejected(t *testing.T) {
+	node, engine, recipients, done := newPayout(t, 3)
+	defer done()
+	node.reject = true
+	statuses, SDKRes := engine.Run(recipients)
+	if SDKRes.ErrorCode != 93 {
+		t.Fatal("refused transaction is accepted:", SDKRes.ErrorDesc)
+	}
+	for _, status := range statuses {
+		if status.Status != payout.STATUS_PENDING {
+			t.Errorf("wrong status %+v", status)
+		}
+	}
+	// the refused nonce is used again
+	node.reject = false
+	statuses, SDKRes = engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 || node.nonce != 9 {
+		t.Fatal(SDKRes.ErrorDesc, node.nonce)
+	}
+	checkPaid(t, node, statuses)
+
+	// a payment that cannot be built fails alone
+	recipients = append(recipients, payout.PayoutRecipient{ID: "x", Address: recipients[0].Address, Amount: "0"})
+	statuses, SDKRes = engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 || statuses[3].Status != payout.STATUS_FAILED || statuses[3].ErrorCode != 11026 {
+		t.Errorf("wrong status %+v %s", statuses[3], SDKRes.ErrorDesc)
+	}
+}
+
+func Test_PayoutFailed(t *testing.T) {
+	node, engine, recipients, done := newPayout(t, 3)
+	defer done()
+	// the transactions are in a ledger but apply nothing
+	node.fail = true
+	statuses, SDKRes := engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	for _, status := range statuses {
+		if status.Status != payout.STATUS_PENDING || status.Hash == "" || status.ErrorCode != 100 {
+			t.Errorf("wrong status %+v", status)
+		}
+	}
+	// the recipients are paid in new transactions
+	node.fail = false
+	statuses, SDKRes = engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 || node.nonce != 11 {
+		t.Fatal(SDKRes.ErrorDesc, node.nonce)
+	}
+	checkPaid(t, node, statuses)
+}
+
+func Test_PayoutBaseReserve(t *testing.T) {
+	node, engine, recipients, done := newPayout(t, 2)
+	defer done()
+	// below the base reserve of 0.1 BU: a payment to an account an earlier
+	// payment creates is made, one to another new account fails
+	_, _, address, _ := keypair.Create()
+	recipients = append(recipients,
+		payout.PayoutRecipient{ID: "again", Address: recipients[1].Address, Amount: "0.05"},
+		payout.PayoutRecipient{ID: "small", Address: address, Amount: "0.05"})
+	statuses, SDKRes := engine.Run(recipients)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if statuses[2].Status != payout.STATUS_PAID || node.payments[recipients[1].Address] != 155000000 {
+		t.Errorf("wrong status %+v", statuses[2])
+	}
+	if statuses[3].Status != payout.STATUS_FAILED || statuses[3].ErrorCode != 11026 || node.payments[address] != 0 {
+		t.Errorf("wrong status %+v", statuses[3])
+	}
+}
+
+func Test_ReadRecipients(t *testing.T) {
+	_, _, address, _ := keypair.Create()
+	_, _, issuer, _ := keypair.Create()
+	recipients, SDKRes := payout.ReadRecipientsCSV(strings.NewReader("address,amount,code,issuer\n"+
+		address+",1.5,,\n"+address+",2,"+"HNC,"+issuer+"\n"), model.Network{})
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if len(recipients) != 2 || recipients[0].ID != "2" || recipients[1].ID != "3" || recipients[1].Issuer != issuer {
+		t.Errorf("wrong recipients %+v", recipients)
+	}
+	recipients, SDKRes = payout.ReadRecipientsJSON(strings.NewReader(`[{"address":"`+address+`","amount":"1"},{"id":"b","address":"`+address+`","amount":"2"}]`), model.Network{})
+	if SDKRes.ErrorCode != 0 || recipients[0].ID != "1" || recipients[1].ID != "b" {
+		t.Errorf("wrong recipients %+v %s", recipients, SDKRes.ErrorDesc)
+	}
+	_, SDKRes = payout.ReadRecipientsJSON(strings.NewReader(`[{"id":"a","address":"`+address+`","amount":"1"},{"id":"a","address":"`+address+`","amount":"2"}]`), model.Network{})
+	if SDKRes.ErrorCode != 11090 {
+		t.Error("duplicate id is accepted")
+	}
+	_, SDKRes = payout.ReadRecipientsCSV(strings.NewReader("address\n"+address+"\n"), model.Network{})
+	if SDKRes.ErrorCode != 11090 {
+		t.Error("missing amount column is accepted")
+	}
+}
+
+func Test_FileJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	journal := &payout.FileJournal{Path: filepath.Join(dir, "journal")}
+	journal.Save(payout.PayoutBatch{ID: 0, Nonce: 8, State: payout.BATCH_SIGNED})
+	journal.Save(payout.PayoutBatch{ID: 1, Nonce: 9, State: payout.BATCH_SIGNED})
+	journal.Save(payout.PayoutBatch{ID: 0, Nonce: 8, State: payout.BATCH_CONFIRMED})
+	// a line cut short by a crash
+	file, _ := os.OpenFile(journal.Path, os.O_WRONLY|os.O_APPEND, 0600)
+	file.WriteString(`{"id":2,"nonce":10,"sta`)
+	file.Close()
+	batches, err := journal.Load()
+	if err != nil || len(batches) != 2 || batches[0].State != payout.BATCH_CONFIRMED {
+		t.Fatalf("wrong batches %+v %v", batches, err)
+	}
+	journal.Save(payout.PayoutBatch{ID: 2, Nonce: 10, State: payout.BATCH_SIGNED})
+	batches, err = journal.Load()
+	if err != nil || len(batches) != 3 || batches[2].Nonce != 10 {
+		t.Errorf("wrong batches %+v %v", batches, err)
+	}
+}
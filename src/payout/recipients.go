@@ -0,0 +1,148 @@
+// recipients
+package payout
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// PayoutRecipient is one payment of a payout. Without Code and
+// ContractAddress it pays BU; with Code and Issuer it pays an asset, and with
+// ContractAddress a CTP10 token. Amount is a decimal amount, e.g. "12.5", in
+// the decimals of BU, of the ATP 1.0 metadata of the asset (base units for
+// assets without it) or of the token.
+//
+// ID identifies the payment in the journal and must not change between runs:
+// a payment whose ID is in the journal is never paid again.
+type PayoutRecipient struct {
+	ID              string `json:"id"`
+	Address         string `json:"address"`
+	Amount          string `json:"amount"`
+	Code            string `json:"code,omitempty"`
+	Issuer          string `json:"issuer,omitempty"`
+	ContractAddress string `json:"contract_address,omitempty"`
+}
+
+// Check checks the recipient, its addresses against the prefix of network.
+func (recipient PayoutRecipient) Check(network model.Network) exception.SDKResponse {
+	desc := ""
+	switch {
+	case recipient.ID == "":
+		desc = "id is empty"
+	case !network.CheckAddress(recipient.Address):
+		desc = "invalid address " + recipient.Address
+	case recipient.Amount == "":
+		desc = "amount is empty"
+	case recipient.Code != "" && recipient.ContractAddress != "":
+		desc = "code and contract_address are both set"
+	case recipient.Code != "" && !network.CheckAddress(recipient.Issuer):
+		desc = "invalid issuer " + recipient.Issuer
+	case recipient.Code == "" && recipient.Issuer != "":
+		desc = "issuer without code"
+	case recipient.ContractAddress != "" && !network.CheckAddress(recipient.ContractAddress):
+		desc = "invalid contract_address " + recipient.ContractAddress
+	}
+	if desc != "" {
+		SDKRes := exception.GetSDKRes(exception.INVALID_PAYOUT_RECIPIENT_ERROR)
+		SDKRes.ErrorDesc += ": " + recipient.ID + ": " + desc
+		return SDKRes
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+// ReadRecipientsCSV reads recipients from CSV with a header line naming the
+// columns: address and amount, and optionally id, code, issuer and
+// contract_address. Without an id column, the line number is the ID, so the
+// file must not be reordered between runs. Addresses are checked against the
+// prefix of network.
+func ReadRecipientsCSV(reader io.Reader, network model.Network) ([]PayoutRecipient, exception.SDKResponse) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	lines, err := csvReader.ReadAll()
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.INVALID_PAYOUT_RECIPIENT_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return nil, SDKRes
+	}
+	if len(lines) == 0 {
+		return nil, exception.GetSDKRes(exception.SUCCESS)
+	}
+	columns := make(map[string]int)
+	for i, name := range lines[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"address", "amount"} {
+		if _, ok := columns[name]; !ok {
+			SDKRes := exception.GetSDKRes(exception.INVALID_PAYOUT_RECIPIENT_ERROR)
+			SDKRes.ErrorDesc += ": no " + name + " column"
+			return nil, SDKRes
+		}
+	}
+	value := func(line []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(line) {
+			return ""
+		}
+		return strings.TrimSpace(line[i])
+	}
+	recipients := make([]PayoutRecipient, 0, len(lines)-1)
+	for n, line := range lines[1:] {
+		recipient := PayoutRecipient{
+			ID:              value(line, "id"),
+			Address:         value(line, "address"),
+			Amount:          value(line, "amount"),
+			Code:            value(line, "code"),
+			Issuer:          value(line, "issuer"),
+			ContractAddress: value(line, "contract_address"),
+		}
+		if _, ok := columns["id"]; !ok {
+			recipient.ID = strconv.Itoa(n + 2)
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, checkRecipients(recipients, network)
+}
+
+// ReadRecipientsJSON reads recipients from a JSON array of objects with the
+// members of PayoutRecipient. Without an id member, the position in the
+// array, from 1, is the ID. Addresses are checked against the prefix of
+// network.
+func ReadRecipientsJSON(reader io.Reader, network model.Network) ([]PayoutRecipient, exception.SDKResponse) {
+	var recipients []PayoutRecipient
+	err := json.NewDecoder(reader).Decode(&recipients)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.INVALID_PAYOUT_RECIPIENT_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return nil, SDKRes
+	}
+	for i := range recipients {
+		if recipients[i].ID == "" {
+			recipients[i].ID = strconv.Itoa(i + 1)
+		}
+	}
+	return recipients, checkRecipients(recipients, network)
+}
+
+// checkRecipients checks every recipient and that no ID is used twice.
+func checkRecipients(recipients []PayoutRecipient, network model.Network) exception.SDKResponse {
+	ids := make(map[string]bool)
+	for _, recipient := range recipients {
+		SDKRes := recipient.Check(network)
+		if SDKRes.ErrorCode != 0 {
+			return SDKRes
+		}
+		if ids[recipient.ID] {
+			SDKRes := exception.GetSDKRes(exception.INVALID_PAYOUT_RECIPIENT_ERROR)
+			SDKRes.ErrorDesc += ": " + recipient.ID + ": duplicate id"
+			return SDKRes
+		}
+		ids[recipient.ID] = true
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
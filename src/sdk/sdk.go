@@ -2,6 +2,8 @@
 package sdk
 
 import (
+	"encoding/hex"
+
 	"github.com/bumoproject/bumo-sdk-go/src/account"
 	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
 	"github.com/bumoproject/bumo-sdk-go/src/common"
@@ -17,6 +19,8 @@ type Sdk struct {
 	Transaction blockchain.TransactionOperation
 	Block       blockchain.BlockOperation
 	Token       token.TokenOperation
+	Scanner     blockchain.LedgerScanner
+	Network     model.Network
 }
 
 //Init
@@ -27,6 +31,13 @@ func (sdk *Sdk) Init(reqData model.SDKInitRequest) model.SDKInitResponse {
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
+	network := reqData.GetNetwork().Resolve()
+	SDKRes := checkNetwork(network)
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
 	get := "/hello"
 	response, SDKRes := common.GetRequest(reqData.GetUrl(), get, "")
 	if SDKRes.ErrorCode != 0 {
@@ -45,7 +56,47 @@ func (sdk *Sdk) Init(reqData model.SDKInitRequest) model.SDKInitResponse {
 	sdk.Token.Asset.Url = reqData.GetUrl()
 	sdk.Transaction.Url = reqData.GetUrl()
 	sdk.Block.Url = reqData.GetUrl()
+	sdk.Scanner.Url = reqData.GetUrl()
 	sdk.Token.Ctp10Token.Url = reqData.GetUrl()
+	sdk.Token.Atp10.Url = reqData.GetUrl()
+	sdk.Token.Portfolio.Url = reqData.GetUrl()
+	if sdk.Token.Standards == nil {
+		sdk.Token.Standards = token.NewStandardRegistry()
+	}
+	sdk.Network = network
+	sdk.Account.Network = network
+	sdk.Contract.Network = network
+	sdk.Token.Asset.Network = network
+	sdk.Transaction.Network = network
+	sdk.Block.Network = network
+	sdk.Scanner.Network = network
+	sdk.Token.Ctp10Token.Network = network
+	sdk.Token.Atp10.Network = network
+	sdk.Token.Portfolio.Network = network
 	resData.ErrorCode = exception.SUCCESS
 	return resData
 }
+
+// checkNetwork checks the addresses and the values of a resolved network.
+func checkNetwork(network model.Network) exception.SDKResponse {
+	desc := ""
+	prefix, err := hex.DecodeString(network.AddressPrefix)
+	switch {
+	case err != nil || len(prefix) == 0:
+		desc = "invalid address prefix " + network.AddressPrefix
+	case !network.CheckAddress(network.RewardContract):
+		desc = "invalid reward contract " + network.RewardContract
+	case network.QueryFeeLimit < 0 || network.QueryGasPrice < 0:
+		desc = "negative query fee"
+	case network.FeeLimit < 0 || network.GasPrice < 0:
+		desc = "negative transaction fee"
+	case network.MaxPayloadSize < 0:
+		desc = "negative payload size"
+	}
+	if desc != "" {
+		SDKRes := exception.GetSDKRes(exception.INVALID_NETWORK_ERROR)
+		SDKRes.ErrorDesc += ": " + desc
+		return SDKRes
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
@@ -0,0 +1,224 @@
+// sweep
+package sweep
+
+import (
+	"encoding/hex"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/blockchain"
+	"github.com/bumoproject/bumo-sdk-go/src/common"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/merkle"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/bumoproject/bumo-sdk-go/src/token"
+)
+
+// SweepResult is the sweep of one deposit address: what was moved, or in a
+// dry run what would be moved, by the transaction of Hash.
+type SweepResult struct {
+	Address string
+	// the BU moved, what is left above the base reserve after the fee limit
+	BU     model.Amount
+	Assets []model.PortfolioHolding
+	Tokens []model.PortfolioHolding
+	// tokens whose balance could not be read, left where they are
+	Skipped  []model.PortfolioHolding
+	GasPrice int64
+	FeeLimit int64
+	Blob     string
+	Hash     string
+	// Submitted is false in a dry run and when the sweep failed
+	Submitted bool
+	ErrorCode int
+	ErrorDesc string
+}
+
+// Sweeper moves the funds of deposit addresses to a destination, such as the
+// hot wallet of an exchange: all assets, the balances of the tokens of
+// Tokens, and the BU above the base reserve once the fee is paid. Each
+// address is swept by one transaction it signs itself. With DryRun set, the
+// transactions are built and signed but not submitted.
+type Sweeper struct {
+	Url         string
+	Network     model.Network
+	Destination string
+	// the CTP10 tokens to sweep; accounts do not list the tokens they hold
+	Tokens   *token.TokenRegistry
+	Metadata string
+	DryRun   bool
+}
+
+// Sweep sweeps the address of every private key, in order. Each result
+// carries its own error; Sweep itself only fails when the destination is
+// invalid or the fees of the chain cannot be read. Submitted transactions
+// are not waited for.
+func (sweeper *Sweeper) Sweep(privateKeys []string) ([]SweepResult, exception.SDKResponse) {
+	if !sweeper.Network.CheckAddress(sweeper.Destination) {
+		return nil, exception.GetSDKRes(exception.INVALID_DESTADDRESS_ERROR)
+	}
+	gasPrice, baseReserve, SDKRes := common.GetLatestFees(sweeper.Url)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	estimator := &blockchain.FeeEstimator{Url: sweeper.Url, Network: sweeper.Network, FeeConfig: protocol.FeeConfig{GasPrice: gasPrice, BaseReserve: baseReserve}}
+	results := make([]SweepResult, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		results[i] = sweeper.sweep(privateKey, estimator, baseReserve)
+	}
+	return results, exception.GetSDKRes(exception.SUCCESS)
+}
+
+func (sweeper *Sweeper) sweep(privateKey string, estimator *blockchain.FeeEstimator, baseReserve int64) SweepResult {
+	result := SweepResult{BU: model.NewBUAmount(0)}
+	fail := func(SDKRes exception.SDKResponse) SweepResult {
+		result.ErrorCode = SDKRes.ErrorCode
+		result.ErrorDesc = SDKRes.ErrorDesc
+		return result
+	}
+	if !keypair.CheckPrivateKey(privateKey) {
+		return fail(exception.GetSDKRes(exception.PRIVATEKEY_ONE_ERROR))
+	}
+	publicKey, err := keypair.GetEncPublicKey(privateKey)
+	if err == nil {
+		result.Address, err = sweeper.Network.EncodeAddress(publicKey)
+	}
+	if err != nil {
+		return fail(exception.GetSDKRes(exception.PRIVATEKEY_ONE_ERROR))
+	}
+	if result.Address == sweeper.Destination {
+		return fail(exception.GetSDKRes(exception.SOURCEADDRESS_EQUAL_DESTADDRESS_ERROR))
+	}
+	portfolioOperation := token.PortfolioOperation{Url: sweeper.Url, Network: sweeper.Network}
+	portfolio, SDKRes := portfolioOperation.Get(result.Address, sweeper.Tokens)
+	if SDKRes.ErrorCode != 0 {
+		return fail(SDKRes)
+	}
+
+	// assets are moved in base units, even when their decimals are unknown
+	var operations []model.BaseOperation
+	for _, holding := range portfolio.Assets {
+		units, err := holding.Balance.Units()
+		if err != nil || units <= 0 {
+			continue
+		}
+		var operation model.AssetSendOperation
+		operation.Init()
+		operation.SetDestAddress(sweeper.Destination)
+		operation.SetCode(holding.Code)
+		operation.SetIssuer(holding.Issuer)
+		operation.SetAmount(units)
+		operations = append(operations, operation)
+		result.Assets = append(result.Assets, holding)
+	}
+	for _, holding := range portfolio.Tokens {
+		if holding.ErrorCode != 0 {
+			result.Skipped = append(result.Skipped, holding)
+			continue
+		}
+		units, err := holding.Balance.Units()
+		if err != nil || units <= 0 {
+			continue
+		}
+		var operation model.Ctp10TokenTransferOperation
+		operation.Init()
+		operation.SetSourceAddress(result.Address)
+		operation.SetContractAddress(holding.ContractAddress)
+		operation.SetDestAddress(sweeper.Destination)
+		operation.SetAmount(units)
+		operations = append(operations, operation)
+		result.Tokens = append(result.Tokens, holding)
+	}
+
+	Account := account.AccountOperation{Url: sweeper.Url, Network: sweeper.Network}
+	var reqDataNonce model.AccountGetNonceRequest
+	reqDataNonce.SetAddress(result.Address)
+	resDataNonce := Account.GetNonce(reqDataNonce)
+	if resDataNonce.ErrorCode != 0 {
+		return fail(exception.SDKResponse{ErrorCode: resDataNonce.ErrorCode, ErrorDesc: resDataNonce.ErrorDesc})
+	}
+	nonce := resDataNonce.Result.Nonce + 1
+
+	// the fee is estimated with the whole spendable balance as the BU amount,
+	// whose encoding is at least as long as the amount finally sent
+	balance, err := portfolio.BU.Units()
+	if err != nil {
+		return fail(exception.GetSDKRes(exception.INVALID_BU_AMOUNT_ERROR))
+	}
+	spendable := balance - baseReserve
+	if spendable <= 0 {
+		return fail(exception.GetSDKRes(exception.SWEEP_INSUFFICIENT_BALANCE_ERROR))
+	}
+	send := sweeper.buSend(spendable)
+	var reqDataFee model.TransactionEvaluateFeeRequest
+	reqDataFee.SetSourceAddress(result.Address)
+	reqDataFee.SetNonce(nonce)
+	reqDataFee.SetMetadata(sweeper.Metadata)
+	for _, operation := range operations {
+		reqDataFee.AddOperation(operation)
+	}
+	reqDataFee.AddOperation(send)
+	resDataFee := estimator.EstimateFee(reqDataFee)
+	if resDataFee.ErrorCode != 0 {
+		return fail(exception.SDKResponse{ErrorCode: resDataFee.ErrorCode, ErrorDesc: resDataFee.ErrorDesc})
+	}
+	result.GasPrice = resDataFee.Result.GasPrice
+	result.FeeLimit = resDataFee.Result.FeeLimit
+	amount := spendable - result.FeeLimit
+	if amount < 0 || amount == 0 && len(operations) == 0 {
+		return fail(exception.GetSDKRes(exception.SWEEP_INSUFFICIENT_BALANCE_ERROR))
+	}
+	if amount > 0 {
+		operations = append(operations, sweeper.buSend(amount))
+		result.BU = model.NewBUAmount(amount)
+	}
+
+	transaction := blockchain.TransactionOperation{Url: sweeper.Url, Network: sweeper.Network}
+	var reqDataBlob model.TransactionBuildBlobRequest
+	reqDataBlob.SetSourceAddress(result.Address)
+	reqDataBlob.SetNonce(nonce)
+	reqDataBlob.SetGasPrice(result.GasPrice)
+	reqDataBlob.SetFeeLimit(result.FeeLimit)
+	reqDataBlob.SetMetadata(sweeper.Metadata)
+	for _, operation := range operations {
+		reqDataBlob.AddOperation(operation)
+	}
+	resDataBlob := transaction.BuildBlob(reqDataBlob)
+	if resDataBlob.ErrorCode != 0 {
+		return fail(exception.SDKResponse{ErrorCode: resDataBlob.ErrorCode, ErrorDesc: resDataBlob.ErrorDesc})
+	}
+	var reqDataSign model.TransactionSignRequest
+	reqDataSign.SetBlob(resDataBlob.Result.Blob)
+	reqDataSign.SetPrivateKeys([]string{privateKey})
+	resDataSign := transaction.Sign(reqDataSign)
+	if resDataSign.ErrorCode != 0 {
+		return fail(exception.SDKResponse{ErrorCode: resDataSign.ErrorCode, ErrorDesc: resDataSign.ErrorDesc})
+	}
+	data, err := hex.DecodeString(resDataBlob.Result.Blob)
+	if err != nil {
+		return fail(exception.GetSDKRes(exception.INVALID_BLOB_ERROR))
+	}
+	result.Blob = resDataBlob.Result.Blob
+	result.Hash = hex.EncodeToString(merkle.Hash(data))
+	if sweeper.DryRun {
+		return result
+	}
+	var reqDataSubmit model.TransactionSubmitRequest
+	reqDataSubmit.SetBlob(resDataBlob.Result.Blob)
+	reqDataSubmit.SetSignatures(resDataSign.Result.Signatures)
+	resDataSubmit := transaction.Submit(reqDataSubmit)
+	if resDataSubmit.ErrorCode != 0 {
+		return fail(exception.SDKResponse{ErrorCode: resDataSubmit.ErrorCode, ErrorDesc: resDataSubmit.ErrorDesc})
+	}
+	result.Submitted = true
+	return result
+}
+
+func (sweeper *Sweeper) buSend(amount int64) model.BUSendOperation {
+	var operation model.BUSendOperation
+	operation.Init()
+	operation.SetDestAddress(sweeper.Destination)
+	operation.SetAmount(amount)
+	return operation
+}
@@ -0,0 +1,100 @@
+// sweep_test
+package sweep_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/protocol"
+	"github.com/bumoproject/bumo-sdk-go/src/sweep"
+	"github.com/golang/protobuf/proto"
+)
+
+const issuerAddress = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+
+// a node where the rich address holds 0.5 BU and 700 HNC, the poor address
+// only the base reserve of 0.1 BU
+func sweepNode(rich string, poor string, submitted *[]protocol.Transaction) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/getLedger":
+			w.Write([]byte(`{"error_code":0,"result":{"fees":{"gas_price":1000,"base_reserve":10000000}}}`))
+		case r.URL.Path == "/submitTransaction":
+			var request struct {
+				Items []struct {
+					Blob string `json:"transaction_blob"`
+				} `json:"items"`
+			}
+			data, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(data, &request)
+			blob, _ := hex.DecodeString(request.Items[0].Blob)
+			var transaction protocol.Transaction
+			proto.Unmarshal(blob, &transaction)
+			*submitted = append(*submitted, transaction)
+			w.Write([]byte(`{"results":[{"error_code":0,"hash":"h"}],"success_count":1}`))
+		case query.Get("address") == rich:
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + rich + `","balance":50000000,"nonce":3,
+				"assets":[{"amount":700,"key":{"code":"HNC","issuer":"` + issuerAddress + `"}}]}}`))
+		case query.Get("address") == poor:
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + poor + `","balance":10000000,"nonce":1}}`))
+		case query.Get("address") == issuerAddress:
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + issuerAddress + `"}}`))
+		default:
+			w.Write([]byte(`{"error_code":4}`))
+		}
+	}))
+}
+
+func Test_Sweep(t *testing.T) {
+	_, richKey, rich, _ := keypair.Create()
+	_, poorKey, poor, _ := keypair.Create()
+	_, _, hotWallet, _ := keypair.Create()
+	var submitted []protocol.Transaction
+	server := sweepNode(rich, poor, &submitted)
+	defer server.Close()
+
+	sweeper := sweep.Sweeper{Url: server.URL, Destination: hotWallet, DryRun: true}
+	results, SDKRes := sweeper.Sweep([]string{richKey, poorKey})
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	swept, left := results[0], results[1]
+	if swept.ErrorCode != 0 || swept.Address != rich || swept.Submitted || swept.Blob == "" || len(submitted) != 0 {
+		t.Fatalf("wrong dry run %+v", swept)
+	}
+	units, _ := swept.BU.Units()
+	if swept.FeeLimit <= 0 || units+swept.FeeLimit != 40000000 {
+		t.Errorf("%d swept with a fee limit of %d", units, swept.FeeLimit)
+	}
+	if len(swept.Assets) != 1 || swept.Assets[0].Code != "HNC" {
+		t.Errorf("wrong assets %+v", swept.Assets)
+	}
+	if left.ErrorCode != 11092 || left.Address != poor {
+		t.Errorf("poor address is swept %+v", left)
+	}
+
+	sweeper.DryRun = false
+	results, SDKRes = sweeper.Sweep([]string{richKey})
+	if SDKRes.ErrorCode != 0 || !results[0].Submitted || len(submitted) != 1 {
+		t.Fatalf("not submitted %+v %s", results, SDKRes.ErrorDesc)
+	}
+	transaction := submitted[0]
+	if transaction.SourceAddress != rich || transaction.Nonce != 4 || len(transaction.Operations) != 2 {
+		t.Fatalf("wrong transaction %+v", transaction)
+	}
+	asset, bu := transaction.Operations[0].GetPayAsset(), transaction.Operations[1].GetPayCoin()
+	if asset.GetDestAddress() != hotWallet || asset.GetAsset().GetAmount() != 700 || bu.GetDestAddress() != hotWallet || bu.GetAmount() != units {
+		t.Errorf("wrong operations %+v", transaction.Operations)
+	}
+
+	sweeper.Destination = "bu"
+	if _, SDKRes = sweeper.Sweep([]string{richKey}); SDKRes.ErrorCode != 11003 {
+		t.Error("invalid destination is accepted:", SDKRes.ErrorDesc)
+	}
+}
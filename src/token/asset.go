@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 
 	"github.com/bumoproject/bumo-sdk-go/src/common"
-	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
 	"github.com/bumoproject/bumo-sdk-go/src/exception"
 	"github.com/bumoproject/bumo-sdk-go/src/model"
 )
@@ -14,15 +13,20 @@ import (
 type TokenOperation struct {
 	Asset      AssetOperation
 	Ctp10Token Ctp10TokenOperation
+	Atp10      Atp10Operation
+	Portfolio  PortfolioOperation
+	// the token standards Detect knows, CTP 1.0 after sdk.Init
+	Standards *StandardRegistry
 }
 type AssetOperation struct {
-	Url string
+	Url     string
+	Network model.Network
 }
 
 //获取账户指定资产数量
 func (asset *AssetOperation) GetInfo(reqData model.AssetGetInfoRequest) model.AssetGetInfoResponse {
 	var resData model.AssetGetInfoResponse
-	if !keypair.CheckAddress(reqData.GetAddress()) {
+	if !asset.Network.CheckAddress(reqData.GetAddress()) {
 		resData.ErrorCode = exception.INVALID_ADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -32,7 +36,7 @@ func (asset *AssetOperation) GetInfo(reqData model.AssetGetInfoRequest) model.As
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetIssuer()) {
+	if !asset.Network.CheckAddress(reqData.GetIssuer()) {
 		resData.ErrorCode = exception.INVALID_ISSUER_ADDRESS_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
@@ -0,0 +1,240 @@
+// atp10
+package token
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+const (
+	// STANDARD_ATP10 is the name of the ATP 1.0 asset standard.
+	STANDARD_ATP10 string = "ATP10"
+	// ATP10_VERSION is the version an ATP 1.0 asset declares in its metadata.
+	ATP10_VERSION string = "1.0"
+	// ATP10_METADATA_PREFIX prefixes the asset code in the metadata key of the
+	// issuer holding the asset properties.
+	ATP10_METADATA_PREFIX string = "asp_"
+	// ATP10_ISSUED_PREFIX prefixes the asset code in the metadata key where
+	// the SDK records the amount issued so far. It is outside the keys of
+	// ATP10_METADATA_PREFIX, so no asset code maps to it.
+	ATP10_ISSUED_PREFIX string = "atp10_issued_"
+	// the key used by earlier versions of the ATP10 demo
+	atp10LegacyPrefix string = "asset_property_"
+)
+
+// Atp10Operation issues ATP 1.0 assets: assets issued by an account, described
+// by a JSON metadata of the issuer.
+type Atp10Operation struct {
+	Url     string
+	Network model.Network
+}
+
+// CheckAtp10Metadata checks the version, code, name, decimals and total
+// supply of asset metadata.
+func CheckAtp10Metadata(metadata model.Atp10Metadata) exception.SDKResponse {
+	desc := ""
+	switch {
+	case metadata.Version != ATP10_VERSION:
+		desc = "version must be " + ATP10_VERSION
+	case len(metadata.Code) == 0 || len(metadata.Code) > 64:
+		desc = "the length of code must be between 1 and 64"
+	case len(metadata.Name) == 0 || len(metadata.Name) > 1024:
+		desc = "the length of name must be between 1 and 1024"
+	case len(metadata.Description) > 1024:
+		desc = "the length of description must be at most 1024"
+	case metadata.Decimals < 0 || metadata.Decimals > 8:
+		desc = "decimals must be between 0 and 8"
+	case metadata.TotalSupply < 0:
+		desc = "totalSupply must not be negative"
+	}
+	if desc != "" {
+		SDKRes := exception.GetSDKRes(exception.INVALID_ATP10_METADATA_ERROR)
+		SDKRes.ErrorDesc += ": " + desc
+		return SDKRes
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+// GetInfo reads the metadata of the asset and the amount issued so far. The
+// amount is the record the SDK keeps when it issues; for an asset issued
+// without it, the balance of the issuer is used, which is less than the
+// issued amount once the issuer has sent some.
+func (atp10 *Atp10Operation) GetInfo(issuer string, code string) (model.Atp10Info, exception.SDKResponse) {
+	info := model.Atp10Info{Issuer: issuer}
+	if !atp10.Network.CheckAddress(issuer) {
+		return info, exception.GetSDKRes(exception.INVALID_ISSUER_ADDRESS_ERROR)
+	}
+	var metadata model.Metadata
+	var SDKRes exception.SDKResponse
+	for _, prefix := range []string{ATP10_METADATA_PREFIX, atp10LegacyPrefix} {
+		metadata, SDKRes = atp10.getMetadata(issuer, prefix+code)
+		if SDKRes.ErrorCode != exception.NO_METADATA_ERROR {
+			break
+		}
+	}
+	if SDKRes.ErrorCode == exception.NO_METADATA_ERROR {
+		return info, exception.GetSDKRes(exception.ATP10_ASSET_NOT_FOUND_ERROR)
+	}
+	if SDKRes.ErrorCode != 0 {
+		return info, SDKRes
+	}
+	info.MetadataKey = metadata.Key
+	err := json.Unmarshal([]byte(metadata.Value), &info.Metadata)
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.INVALID_ATP10_METADATA_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return info, SDKRes
+	}
+	if info.Metadata.Code == "" {
+		info.Metadata.Code = code
+	}
+
+	issued, SDKRes := atp10.getMetadata(issuer, ATP10_ISSUED_PREFIX+code)
+	if SDKRes.ErrorCode == 0 {
+		info.Issued, err = strconv.ParseInt(issued.Value, 10, 64)
+		if err != nil {
+			SDKRes := exception.GetSDKRes(exception.INVALID_ATP10_METADATA_ERROR)
+			SDKRes.ErrorDesc += ": " + issued.Key + " is not an amount"
+			return info, SDKRes
+		}
+		info.IssuedRecorded = true
+		info.IssuedVersion = issued.Version
+		return info, SDKRes
+	}
+	if SDKRes.ErrorCode != exception.NO_METADATA_ERROR {
+		return info, SDKRes
+	}
+	asset := AssetOperation{Url: atp10.Url, Network: atp10.Network}
+	var reqDataAsset model.AssetGetInfoRequest
+	reqDataAsset.SetAddress(issuer)
+	reqDataAsset.SetIssuer(issuer)
+	reqDataAsset.SetCode(code)
+	resDataAsset := asset.GetInfo(reqDataAsset)
+	if resDataAsset.ErrorCode == 0 && len(resDataAsset.Result.Assets) != 0 {
+		info.Issued = resDataAsset.Result.Assets[0].Amount
+	} else if resDataAsset.ErrorCode != 0 && resDataAsset.ErrorCode != exception.NO_ASSET_ERROR {
+		return info, exception.SDKResponse{ErrorCode: resDataAsset.ErrorCode, ErrorDesc: resDataAsset.ErrorDesc}
+	}
+	return info, exception.GetSDKRes(exception.SUCCESS)
+}
+
+func (atp10 *Atp10Operation) getMetadata(address string, key string) (model.Metadata, exception.SDKResponse) {
+	Account := account.AccountOperation{Url: atp10.Url, Network: atp10.Network}
+	var reqData model.AccountGetMetadataRequest
+	reqData.SetAddress(address)
+	reqData.SetKey(key)
+	resData := Account.GetMetadata(reqData)
+	if resData.ErrorCode != 0 {
+		return model.Metadata{}, exception.SDKResponse{ErrorCode: resData.ErrorCode, ErrorDesc: resData.ErrorDesc}
+	}
+	if len(resData.Result.Metadatas) == 0 {
+		return model.Metadata{}, exception.GetSDKRes(exception.NO_METADATA_ERROR)
+	}
+	return resData.Result.Metadatas[0], exception.GetSDKRes(exception.SUCCESS)
+}
+
+// atp10Units converts an amount to base units of the asset.
+func atp10Units(metadata model.Atp10Metadata, amount model.Amount) (int64, exception.SDKResponse) {
+	units, err := amount.RescaledUnits(int(metadata.Decimals))
+	if err == nil && units <= 0 {
+		err = model.ErrInvalidAmount
+	}
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.INVALID_ISSUE_AMMOUNT_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return 0, SDKRes
+	}
+	return units, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// checkSupply fails when issuing units after issued exceeds the total supply.
+func checkSupply(metadata model.Atp10Metadata, issued int64, units int64) exception.SDKResponse {
+	if metadata.TotalSupply > 0 && (units > metadata.TotalSupply || issued > metadata.TotalSupply-units) {
+		SDKRes := exception.GetSDKRes(exception.ATP10_SUPPLY_EXCEEDED_ERROR)
+		SDKRes.ErrorDesc += ": " + metadata.Amount(issued).String() + " issued, " + metadata.Amount(metadata.TotalSupply).String() + " at most"
+		return SDKRes
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+func issuedOperation(issuer string, code string, issued int64, version int64) model.AccountSetMetadataOperation {
+	var operation model.AccountSetMetadataOperation
+	operation.Init()
+	operation.SetSourceAddress(issuer)
+	operation.SetKey(ATP10_ISSUED_PREFIX + code)
+	operation.SetValue(strconv.FormatInt(issued, 10))
+	operation.SetVersion(version)
+	return operation
+}
+
+// Issue builds the operations of the first issuance of an asset: the asset
+// issuance, the asset metadata and the record of the issued amount. The
+// amount is in the decimals of the metadata, or rescaled to them. It fails
+// when the issuer already has metadata for the code.
+func (atp10 *Atp10Operation) Issue(issuer string, metadata model.Atp10Metadata, amount model.Amount) ([]model.BaseOperation, exception.SDKResponse) {
+	SDKRes := CheckAtp10Metadata(metadata)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	units, SDKRes := atp10Units(metadata, amount)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	SDKRes = checkSupply(metadata, 0, units)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	_, SDKRes = atp10.GetInfo(issuer, metadata.Code)
+	if SDKRes.ErrorCode == 0 {
+		return nil, exception.GetSDKRes(exception.ATP10_ASSET_EXISTS_ERROR)
+	}
+	if SDKRes.ErrorCode != exception.ATP10_ASSET_NOT_FOUND_ERROR {
+		return nil, SDKRes
+	}
+	value, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	var issue model.AssetIssueOperation
+	issue.Init()
+	issue.SetSourceAddress(issuer)
+	issue.SetCode(metadata.Code)
+	issue.SetAmount(units)
+	var setMetadata model.AccountSetMetadataOperation
+	setMetadata.Init()
+	setMetadata.SetSourceAddress(issuer)
+	setMetadata.SetKey(ATP10_METADATA_PREFIX + metadata.Code)
+	setMetadata.SetValue(string(value))
+	operations := []model.BaseOperation{issue, setMetadata, issuedOperation(issuer, metadata.Code, units, 0)}
+	return operations, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// AppendToIssue builds the operations issuing more of an asset: the asset
+// issuance and the new record of the issued amount. It fails when the total
+// supply of the asset would be exceeded. The record is written with the
+// version it was read at, so of two concurrent issuances only one applies.
+func (atp10 *Atp10Operation) AppendToIssue(issuer string, code string, amount model.Amount) ([]model.BaseOperation, exception.SDKResponse) {
+	info, SDKRes := atp10.GetInfo(issuer, code)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	units, SDKRes := atp10Units(info.Metadata, amount)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	SDKRes = checkSupply(info.Metadata, info.Issued, units)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	var issue model.AssetIssueOperation
+	issue.Init()
+	issue.SetSourceAddress(issuer)
+	issue.SetCode(code)
+	issue.SetAmount(units)
+	operations := []model.BaseOperation{issue, issuedOperation(issuer, code, info.Issued+units, info.IssuedVersion)}
+	return operations, exception.GetSDKRes(exception.SUCCESS)
+}
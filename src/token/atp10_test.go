@@ -0,0 +1,113 @@
+// atp10_test
+package token_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/bumoproject/bumo-sdk-go/src/token"
+)
+
+const issuerAddress = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+
+// a node where the issuer has the metadata given by key
+func atp10Node(metadatas map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("code") != "" {
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + issuerAddress + `","assets":[{"amount":400,"key":{"code":"HNC","issuer":"` + issuerAddress + `"}}]}}`))
+			return
+		}
+		value, ok := metadatas[query.Get("key")]
+		if !ok {
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + issuerAddress + `"}}`))
+			return
+		}
+		w.Write([]byte(`{"error_code":0,"result":{"address":"` + issuerAddress + `","metadatas":[{"key":"` + query.Get("key") + `","value":` + value + `,"version":3}]}}`))
+	}))
+}
+
+func Test_Atp10Issue(t *testing.T) {
+	server := atp10Node(map[string]string{})
+	defer server.Close()
+	atp10 := token.Atp10Operation{Url: server.URL}
+	metadata := model.Atp10Metadata{Version: token.ATP10_VERSION, Code: "HNC", Name: "HNC", Decimals: 2, TotalSupply: 100000}
+	amount, _ := metadata.ParseAmount("250.5")
+	operations, SDKRes := atp10.Issue(issuerAddress, metadata, amount)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if len(operations) != 3 {
+		t.Fatalf("%d operations", len(operations))
+	}
+	issue := operations[0].(model.AssetIssueOperation)
+	setMetadata := operations[1].(model.AccountSetMetadataOperation)
+	issued := operations[2].(model.AccountSetMetadataOperation)
+	if issue.GetAmount() != 25050 || issue.GetCode() != "HNC" {
+		t.Errorf("wrong issue %+v", issue)
+	}
+	if setMetadata.GetKey() != "asp_HNC" || issued.GetKey() != "atp10_issued_HNC" || issued.GetValue() != "25050" {
+		t.Errorf("wrong metadata %s %s=%s", setMetadata.GetKey(), issued.GetKey(), issued.GetValue())
+	}
+
+	tooMuch, _ := metadata.ParseAmount("1000.01")
+	if _, SDKRes = atp10.Issue(issuerAddress, metadata, tooMuch); SDKRes.ErrorCode != 11087 {
+		t.Error("issuance above the total supply is built:", SDKRes.ErrorDesc)
+	}
+	metadata.Decimals = 9
+	if _, SDKRes = atp10.Issue(issuerAddress, metadata, amount); SDKRes.ErrorCode != 11086 {
+		t.Error("invalid metadata is accepted:", SDKRes.ErrorDesc)
+	}
+}
+
+func Test_Atp10AppendToIssue(t *testing.T) {
+	server := atp10Node(map[string]string{
+		"asp_HNC":          `"{\"version\":\"1.0\",\"code\":\"HNC\",\"name\":\"HNC\",\"decimals\":2,\"totalSupply\":100000}"`,
+		"atp10_issued_HNC": `"99000"`,
+	})
+	defer server.Close()
+	atp10 := token.Atp10Operation{Url: server.URL}
+	info, SDKRes := atp10.GetInfo(issuerAddress, "HNC")
+	if SDKRes.ErrorCode != 0 || !info.IssuedRecorded || info.Issued != 99000 || info.Metadata.TotalSupply != 100000 {
+		t.Fatalf("wrong info %+v %s", info, SDKRes.ErrorDesc)
+	}
+	operations, SDKRes := atp10.AppendToIssue(issuerAddress, "HNC", model.NewAmount(10, 0))
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	issue := operations[0].(model.AssetIssueOperation)
+	issued := operations[1].(model.AccountSetMetadataOperation)
+	if issue.GetAmount() != 1000 || issued.GetValue() != "100000" || issued.GetVersion() != 3 {
+		t.Errorf("wrong issuance %+v", issued)
+	}
+	if _, SDKRes = atp10.AppendToIssue(issuerAddress, "HNC", model.NewAmount(1001, 2)); SDKRes.ErrorCode != 11087 {
+		t.Error("issuance above the total supply is built:", SDKRes.ErrorDesc)
+	}
+	if _, SDKRes = atp10.Issue(issuerAddress, info.Metadata, model.NewAmount(1, 0)); SDKRes.ErrorCode != 11088 {
+		t.Error("asset is issued twice:", SDKRes.ErrorDesc)
+	}	// the record of HNC is not the metadata of an asset coded issued_HNC
+	if _, SDKRes = atp10.GetInfo(issuerAddress, "issued_HNC"); SDKRes.ErrorCode != 11089 {
+		t.Error("issued record is read as metadata:", SDKRes.ErrorDesc)
+	}
+}
+
+func Test_Atp10LegacyMetadata(t *testing.T) {
+	server := atp10Node(map[string]string{
+		"asset_property_HNC": `"{\"version\":\"1.0\",\"name\":\"HNC\",\"decimals\":1,\"totalSupply\":0}"`,
+	})
+	defer server.Close()
+	atp10 := token.Atp10Operation{Url: server.URL}
+	info, SDKRes := atp10.GetInfo(issuerAddress, "HNC")
+	if SDKRes.ErrorCode != 0 || info.MetadataKey != "asset_property_HNC" || info.Metadata.Code != "HNC" {
+		t.Fatalf("wrong info %+v %s", info, SDKRes.ErrorDesc)
+	}
+	// without a record the balance of the issuer is the issued amount
+	if info.IssuedRecorded || info.Issued != 400 || info.Metadata.Amount(info.Issued).String() != "40" {
+		t.Errorf("wrong issued %+v", info)
+	}
+	if _, SDKRes = atp10.GetInfo(issuerAddress, "XYZ"); SDKRes.ErrorCode != 11089 {
+		t.Error("missing asset is found:", SDKRes.ErrorDesc)
+	}
+}
@@ -0,0 +1,222 @@
+// ctp10
+package token
+
+import (
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// ctp10Interface describes the methods and events of the CTP10 contract of
+// model.Payload.
+var ctp10Interface = contract.ContractInterface{
+	Name: "CTP10",
+	Methods: []contract.ContractMethod{
+		{Name: "name", Query: true, Outputs: []contract.ContractParam{{Name: "name", Type: contract.TYPE_STRING}}},
+		{Name: "symbol", Query: true, Outputs: []contract.ContractParam{{Name: "symbol", Type: contract.TYPE_STRING}}},
+		{Name: "decimals", Query: true, Outputs: []contract.ContractParam{{Name: "decimals", Type: contract.TYPE_INT64}}},
+		{Name: "totalSupply", Query: true, Outputs: []contract.ContractParam{{Name: "totalSupply", Type: contract.TYPE_INT64_STRING}}},
+		{Name: "ctp", Query: true, Outputs: []contract.ContractParam{{Name: "ctp", Type: contract.TYPE_STRING}}},
+		{Name: "contractInfo", Query: true, Outputs: []contract.ContractParam{{Name: "contractInfo", Type: contract.TYPE_OBJECT}}},
+		{
+			Name:    "balanceOf",
+			Query:   true,
+			Inputs:  []contract.ContractParam{{Name: "address", Type: contract.TYPE_ADDRESS}},
+			Outputs: []contract.ContractParam{{Name: "balance", Type: contract.TYPE_INT64_STRING}},
+		},
+		{
+			Name:    "allowance",
+			Query:   true,
+			Inputs:  []contract.ContractParam{{Name: "owner", Type: contract.TYPE_ADDRESS}, {Name: "spender", Type: contract.TYPE_ADDRESS}},
+			Outputs: []contract.ContractParam{{Name: "allowance", Type: contract.TYPE_INT64_STRING}},
+		},
+		{Name: "transfer", Inputs: []contract.ContractParam{{Name: "to", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}}},
+		{
+			Name:   "transferFrom",
+			Inputs: []contract.ContractParam{{Name: "from", Type: contract.TYPE_ADDRESS}, {Name: "to", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}},
+		},
+		{Name: "approve", Inputs: []contract.ContractParam{{Name: "spender", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}}},
+		{Name: "assign", Inputs: []contract.ContractParam{{Name: "to", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}}},
+		{Name: "changeOwner", Inputs: []contract.ContractParam{{Name: "address", Type: contract.TYPE_ADDRESS}}},
+	},
+	Events: []contract.ContractEvent{
+		{Name: "transfer", Inputs: []contract.ContractParam{{Name: "from", Type: contract.TYPE_ADDRESS}, {Name: "to", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}}},
+		{
+			Name:   "transferFrom",
+			Inputs: []contract.ContractParam{{Name: "spender", Type: contract.TYPE_ADDRESS}, {Name: "from", Type: contract.TYPE_ADDRESS}, {Name: "to", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}},
+		},
+		{Name: "approve", Inputs: []contract.ContractParam{{Name: "owner", Type: contract.TYPE_ADDRESS}, {Name: "spender", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}}},
+		{Name: "assign", Inputs: []contract.ContractParam{{Name: "to", Type: contract.TYPE_ADDRESS}, {Name: "value", Type: contract.TYPE_INT64_STRING}}},
+		{Name: "changeOwner", Inputs: []contract.ContractParam{{Name: "owner", Type: contract.TYPE_ADDRESS}, {Name: "address", Type: contract.TYPE_ADDRESS}}},
+	},
+}
+
+// Ctp10Token is a handle on one CTP10 token contract. It checks the contract
+// once and caches its info, runs queries without checking it again, and
+// builds the operations of the token with amounts in its decimals.
+type Ctp10Token struct {
+	bound contract.BoundContract
+	info  model.Ctp10TokenInfo
+}
+
+// Token checks the CTP10 contract at the address and returns a handle on it.
+func (tokenOperation *Ctp10TokenOperation) Token(contractAddress string) (*Ctp10Token, exception.SDKResponse) {
+	token := &Ctp10Token{bound: contract.BoundContract{Url: tokenOperation.Url, Network: tokenOperation.Network, Address: contractAddress, Interface: ctp10Interface}}
+	SDKRes := token.Refresh()
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	return token, SDKRes
+}
+
+// Refresh reads the info of the token again. Name, symbol, decimals and total
+// supply never change, but the owner and the unassigned supply do.
+func (token *Ctp10Token) Refresh() exception.SDKResponse {
+	data, SDKRes := loadCtp10Attribute(contract.ContractOperation{Url: token.bound.Url, Network: token.bound.Network}, token.bound.Address)
+	if SDKRes.ErrorCode != 0 {
+		return SDKRes
+	}
+	decimals := int(data.Decimals)
+	totalSupply, err := model.ParseAmount(data.TotalSupply, 0)
+	if err != nil {
+		return exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	unassigned, err := model.ParseAmount(data.Balance, 0)
+	if err != nil {
+		return exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	token.info = model.Ctp10TokenInfo{
+		Ctp:         data.Ctp,
+		Name:        data.Name,
+		Symbol:      data.Symbol,
+		Decimals:    decimals,
+		TotalSupply: model.NewAmountFromBig(totalSupply.BaseUnits(), decimals),
+		Owner:       data.Ctp10TokenOwner,
+		Unassigned:  model.NewAmountFromBig(unassigned.BaseUnits(), decimals),
+	}
+	return exception.GetSDKRes(exception.SUCCESS)
+}
+
+// ContractAddress returns the address of the token contract.
+func (token *Ctp10Token) ContractAddress() string {
+	return token.bound.Address
+}
+
+// Info returns the cached info of the token.
+func (token *Ctp10Token) Info() model.Ctp10TokenInfo {
+	return token.info
+}
+
+// ParseAmount parses a decimal amount of the token, e.g. "12.5".
+func (token *Ctp10Token) ParseAmount(value string) (model.Amount, error) {
+	return model.ParseAmount(value, token.info.Decimals)
+}
+
+// Balance returns the balance of the address. The balance of the owner is the
+// unassigned supply.
+func (token *Ctp10Token) Balance(address string) (model.Amount, exception.SDKResponse) {
+	outputs, SDKRes := token.bound.Query("balanceOf", address)
+	if SDKRes.ErrorCode != 0 {
+		return model.Amount{}, SDKRes
+	}
+	return model.NewAmount(outputs["balance"].(int64), token.info.Decimals), SDKRes
+}
+
+// Allowance returns how much the spender may still transfer from the owner.
+func (token *Ctp10Token) Allowance(owner string, spender string) (model.Amount, exception.SDKResponse) {
+	outputs, SDKRes := token.bound.Query("allowance", owner, spender)
+	if SDKRes.ErrorCode != 0 {
+		return model.Amount{}, SDKRes
+	}
+	return model.NewAmount(outputs["allowance"].(int64), token.info.Decimals), SDKRes
+}
+
+// units converts an amount to base units of the token. Amounts with other
+// decimals are rescaled as long as no decimal place is lost.
+func (token *Ctp10Token) units(amount model.Amount) (int64, exception.SDKResponse) {
+	units, err := amount.RescaledUnits(token.info.Decimals)
+	if err == nil && units <= 0 {
+		err = model.ErrInvalidAmount
+	}
+	if err != nil {
+		SDKRes := exception.GetSDKRes(exception.INVALID_TOKEN_AMOUNT_ERROR)
+		SDKRes.ErrorDesc += ": " + err.Error()
+		return 0, SDKRes
+	}
+	return units, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// Transfer builds the operation transferring the amount from the source to
+// the destination. An empty source is the source of the transaction.
+func (token *Ctp10Token) Transfer(sourceAddress string, destAddress string, amount model.Amount) (model.Ctp10TokenTransferOperation, exception.SDKResponse) {
+	var operation model.Ctp10TokenTransferOperation
+	units, SDKRes := token.units(amount)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	operation.Init()
+	operation.SetSourceAddress(sourceAddress)
+	operation.SetContractAddress(token.bound.Address)
+	operation.SetDestAddress(destAddress)
+	operation.SetAmount(units)
+	return operation, SDKRes
+}
+
+// TransferFrom builds the operation by which the source, as spender,
+// transfers the amount from the from address to the destination.
+func (token *Ctp10Token) TransferFrom(sourceAddress string, fromAddress string, destAddress string, amount model.Amount) (model.Ctp10TokenTransferFromOperation, exception.SDKResponse) {
+	var operation model.Ctp10TokenTransferFromOperation
+	units, SDKRes := token.units(amount)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	operation.Init()
+	operation.SetSourceAddress(sourceAddress)
+	operation.SetContractAddress(token.bound.Address)
+	operation.SetFromAddress(fromAddress)
+	operation.SetDestAddress(destAddress)
+	operation.SetAmount(units)
+	return operation, SDKRes
+}
+
+// Approve builds the operation allowing the spender to transfer the amount
+// from the source.
+func (token *Ctp10Token) Approve(sourceAddress string, spender string, amount model.Amount) (model.Ctp10TokenApproveOperation, exception.SDKResponse) {
+	var operation model.Ctp10TokenApproveOperation
+	units, SDKRes := token.units(amount)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	operation.Init()
+	operation.SetSourceAddress(sourceAddress)
+	operation.SetContractAddress(token.bound.Address)
+	operation.SetSpender(spender)
+	operation.SetAmount(units)
+	return operation, SDKRes
+}
+
+// Assign builds the operation by which the owner assigns the amount of the
+// unassigned supply to the destination.
+func (token *Ctp10Token) Assign(sourceAddress string, destAddress string, amount model.Amount) (model.Ctp10TokenAssignOperation, exception.SDKResponse) {
+	var operation model.Ctp10TokenAssignOperation
+	units, SDKRes := token.units(amount)
+	if SDKRes.ErrorCode != 0 {
+		return operation, SDKRes
+	}
+	operation.Init()
+	operation.SetSourceAddress(sourceAddress)
+	operation.SetContractAddress(token.bound.Address)
+	operation.SetDestAddress(destAddress)
+	operation.SetAmount(units)
+	return operation, SDKRes
+}
+
+// ChangeOwner builds the operation by which the owner hands the token over.
+func (token *Ctp10Token) ChangeOwner(sourceAddress string, tokenOwner string) model.Ctp10TokenChangeOwnerOperation {
+	var operation model.Ctp10TokenChangeOwnerOperation
+	operation.Init()
+	operation.SetSourceAddress(sourceAddress)
+	operation.SetContractAddress(token.bound.Address)
+	operation.SetTokenOwner(tokenOwner)
+	return operation
+}
@@ -8,110 +8,86 @@ import (
 
 	"github.com/bumoproject/bumo-sdk-go/src/account"
 	"github.com/bumoproject/bumo-sdk-go/src/contract"
-	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
 	"github.com/bumoproject/bumo-sdk-go/src/exception"
 	"github.com/bumoproject/bumo-sdk-go/src/model"
 )
 
 type Ctp10TokenOperation struct {
-	Url string
+	Url     string
+	Network model.Network
 }
 
 //Check Valid
 func (Ctp10Token *Ctp10TokenOperation) CheckValid(reqData model.Ctp10TokenCheckValidRequest) model.Ctp10TokenCheckValidResponse {
-	var Contract contract.ContractOperation
-	Contract.Url = Ctp10Token.Url
-	var Account account.AccountOperation
-	Account.Url = Ctp10Token.Url
 	var resData model.Ctp10TokenCheckValidResponse
 	resData.Result.IsValid = false
+	_, SDKRes := loadCtp10Attribute(contract.ContractOperation{Url: Ctp10Token.Url, Network: Ctp10Token.Network}, reqData.GetContractAddress())
+	if SDKRes.ErrorCode != 0 {
+		resData.ErrorCode = SDKRes.ErrorCode
+		resData.ErrorDesc = SDKRes.ErrorDesc
+		return resData
+	}
+	resData.Result.IsValid = true
+	return resData
+}
+
+// loadCtp10Attribute reads and checks the global attribute a CTP10 contract
+// keeps in its metadata, on the node and network of Contract.
+func loadCtp10Attribute(Contract contract.ContractOperation, contractAddress string) (model.Params, exception.SDKResponse) {
+	var data model.Params
+	var Account account.AccountOperation
+	Account.Url = Contract.Url
+	Account.Network = Contract.Network
 	var raqDataCheck model.ContractCheckValidRequest
-	raqDataCheck.SetAddress(reqData.GetContractAddress())
+	raqDataCheck.SetAddress(contractAddress)
 	resDataCheck := Contract.CheckValid(raqDataCheck)
 	if resDataCheck.ErrorCode != 0 {
-		resData.ErrorCode = resDataCheck.ErrorCode
-		resData.ErrorDesc = resDataCheck.ErrorDesc
-		return resData
+		return data, exception.SDKResponse{ErrorCode: resDataCheck.ErrorCode, ErrorDesc: resDataCheck.ErrorDesc}
 	}
 	if resDataCheck.Result.IsValid == false {
-		resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-		return resData
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
 	}
 	var raqDataMetadata model.AccountGetMetadataRequest
-	raqDataMetadata.SetAddress(reqData.GetContractAddress())
+	raqDataMetadata.SetAddress(contractAddress)
 	raqDataMetadata.SetKey("global_attribute")
 	rasDataMetadata := Account.GetMetadata(raqDataMetadata)
-	if rasDataMetadata.ErrorCode == 0 {
-		var data model.Params
-		strReader := strings.NewReader(rasDataMetadata.Result.Metadatas[0].Value)
-		decoder := json.NewDecoder(strReader)
-		decoder.UseNumber()
-		err := decoder.Decode(&data)
-		if err != nil {
-			SDKRes := exception.GetSDKRes(exception.SYSTEM_ERROR)
-			resData.ErrorCode = SDKRes.ErrorCode
-			resData.ErrorDesc = SDKRes.ErrorDesc
-			return resData
-		}
-		balance, err := strconv.ParseInt(data.Balance, 10, 64)
-		if err != nil {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		if balance <= 0 {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		if data.Decimals < 0 || data.Decimals > 8 {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		if len(data.Name) > 1024 {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		if len(data.Symbol) > 1024 {
-			resData.ErrorCode = exception.INVALID_TOKEN_SIMBOL_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		totalSupply, err := strconv.ParseInt(data.TotalSupply, 10, 64)
-		if err != nil {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		if totalSupply < 0 {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		if !keypair.CheckAddress(data.Ctp10TokenOwner) {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		if data.Ctp != "1.0" {
-			resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-			resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-			return resData
-		}
-		resData.Result.IsValid = true
-		return resData
-	} else if rasDataMetadata.ErrorCode == exception.NO_METADATA_ERROR {
-		resData.ErrorCode = exception.INVALID_CONTRACTADDRESS_ERROR
-		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
-		return resData
-	} else {
-		resData.ErrorCode = rasDataMetadata.ErrorCode
-		resData.ErrorDesc = rasDataMetadata.ErrorDesc
-		return resData
+	if rasDataMetadata.ErrorCode == exception.NO_METADATA_ERROR {
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	if rasDataMetadata.ErrorCode != 0 {
+		return data, exception.SDKResponse{ErrorCode: rasDataMetadata.ErrorCode, ErrorDesc: rasDataMetadata.ErrorDesc}
+	}
+	strReader := strings.NewReader(rasDataMetadata.Result.Metadatas[0].Value)
+	decoder := json.NewDecoder(strReader)
+	decoder.UseNumber()
+	err := decoder.Decode(&data)
+	if err != nil {
+		return data, exception.GetSDKRes(exception.SYSTEM_ERROR)
+	}
+	balance, err := strconv.ParseInt(data.Balance, 10, 64)
+	if err != nil || balance <= 0 {
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	if data.Decimals < 0 || data.Decimals > 8 {
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	if len(data.Name) > 1024 {
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	if len(data.Symbol) > 1024 {
+		return data, exception.GetSDKRes(exception.INVALID_TOKEN_SIMBOL_ERROR)
+	}
+	totalSupply, err := strconv.ParseInt(data.TotalSupply, 10, 64)
+	if err != nil || totalSupply < 0 {
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	if !Contract.Network.CheckAddress(data.Ctp10TokenOwner) {
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	if data.Ctp != "1.0" {
+		return data, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
 	}
+	return data, exception.GetSDKRes(exception.SUCCESS)
 }
 
 //Allowance
@@ -125,20 +101,21 @@ func (Ctp10Token *Ctp10TokenOperation) Allowance(reqData model.Ctp10TokenAllowan
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetTokenOwner()) {
+	if !Ctp10Token.Network.CheckAddress(reqData.GetTokenOwner()) {
 		resData.ErrorCode = exception.INVALID_TOKENOWNER_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetSpender()) {
+	if !Ctp10Token.Network.CheckAddress(reqData.GetSpender()) {
 		resData.ErrorCode = exception.INVALID_SPENDER_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
 	var Contract contract.ContractOperation
 	Contract.Url = Ctp10Token.Url
+	Contract.Network = Ctp10Token.Network
 	var reqDataCall model.ContractCallRequest
-	reqDataCall.SetContractAddress("buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG")
+	reqDataCall.SetContractAddress(reqData.GetContractAddress())
 	reqDataCall.SetOptType(2)
 	var Input model.Input
 	Input.Method = "allowance"
@@ -196,8 +173,9 @@ func (Ctp10Token *Ctp10TokenOperation) GetInfo(reqData model.Ctp10TokenGetInfoRe
 	}
 	var Contract contract.ContractOperation
 	Contract.Url = Ctp10Token.Url
+	Contract.Network = Ctp10Token.Network
 	var reqDataCall model.ContractCallRequest
-	reqDataCall.SetContractAddress("buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG")
+	reqDataCall.SetContractAddress(reqData.GetContractAddress())
 	reqDataCall.SetOptType(2)
 	var Input model.Input
 	Input.Method = "contractInfo"
@@ -256,8 +234,9 @@ func (Ctp10Token *Ctp10TokenOperation) GetName(reqData model.Ctp10TokenGetNameRe
 	}
 	var Contract contract.ContractOperation
 	Contract.Url = Ctp10Token.Url
+	Contract.Network = Ctp10Token.Network
 	var reqDataCall model.ContractCallRequest
-	reqDataCall.SetContractAddress("buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG")
+	reqDataCall.SetContractAddress(reqData.GetContractAddress())
 	reqDataCall.SetOptType(2)
 	var Input model.Input
 	Input.Method = "name"
@@ -309,8 +288,9 @@ func (Ctp10Token *Ctp10TokenOperation) GetSymbol(reqData model.Ctp10TokenGetSymb
 	}
 	var Contract contract.ContractOperation
 	Contract.Url = Ctp10Token.Url
+	Contract.Network = Ctp10Token.Network
 	var reqDataCall model.ContractCallRequest
-	reqDataCall.SetContractAddress("buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG")
+	reqDataCall.SetContractAddress(reqData.GetContractAddress())
 	reqDataCall.SetOptType(2)
 	var Input model.Input
 	Input.Method = "symbol"
@@ -361,8 +341,9 @@ func (Ctp10Token *Ctp10TokenOperation) GetDecimals(reqData model.Ctp10TokenGetDe
 	}
 	var Contract contract.ContractOperation
 	Contract.Url = Ctp10Token.Url
+	Contract.Network = Ctp10Token.Network
 	var reqDataCall model.ContractCallRequest
-	reqDataCall.SetContractAddress("buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG")
+	reqDataCall.SetContractAddress(reqData.GetContractAddress())
 	reqDataCall.SetOptType(2)
 	var Input model.Input
 	Input.Method = "decimals"
@@ -419,8 +400,9 @@ func (Ctp10Token *Ctp10TokenOperation) GetTotalSupply(reqData model.Ctp10TokenGe
 	}
 	var Contract contract.ContractOperation
 	Contract.Url = Ctp10Token.Url
+	Contract.Network = Ctp10Token.Network
 	var reqDataCall model.ContractCallRequest
-	reqDataCall.SetContractAddress("buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG")
+	reqDataCall.SetContractAddress(reqData.GetContractAddress())
 	reqDataCall.SetOptType(2)
 	var Input model.Input
 	Input.Method = "totalSupply"
@@ -475,15 +457,16 @@ func (Ctp10Token *Ctp10TokenOperation) GetBalance(reqData model.Ctp10TokenGetBal
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
-	if !keypair.CheckAddress(reqData.GetTokenOwner()) {
+	if !Ctp10Token.Network.CheckAddress(reqData.GetTokenOwner()) {
 		resData.ErrorCode = exception.INVALID_TOKENOWNER_ERROR
 		resData.ErrorDesc = exception.GetErrDesc(resData.ErrorCode)
 		return resData
 	}
 	var Contract contract.ContractOperation
 	Contract.Url = Ctp10Token.Url
+	Contract.Network = Ctp10Token.Network
 	var reqDataCall model.ContractCallRequest
-	reqDataCall.SetContractAddress("buQXoNR24p2pPqnXPyiDprmTWsU4SYLtBNCG")
+	reqDataCall.SetContractAddress(reqData.GetContractAddress())
 	reqDataCall.SetOptType(2)
 	var Input model.Input
 	Input.Method = "balanceOf"
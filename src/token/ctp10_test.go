@@ -0,0 +1,89 @@
+// ctp10_test
+package token_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/bumoproject/bumo-sdk-go/src/token"
+)
+
+const (
+	contractAddress = "buQhdBSkJqERBSsYiUShUZFMZQhXvkdNgnYq"
+	ownerAddress    = "buQemmMwmRQY1JkcU7w3nhruoX5N3j6C29uo"
+	spenderAddress  = "buQBjJD1BSJ7nzAbzdTenAhpFjmxRVEEtmxH"
+)
+
+// a node serving one CTP10 token with 2 decimals, counting the account reads
+func ctp10Node(reads *int) *httptest.Server {
+	attribute := `{\"ctp\":\"1.0\",\"name\":\"Token\",\"symbol\":\"TKN\",\"decimals\":2,\"totalSupply\":\"100000\",\"contractOwner\":\"` + ownerAddress + `\",\"balance\":\"2500\"}`
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/getAccount" {
+			*reads++
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + contractAddress + `",
+				"priv":{"master_weight":0,"thresholds":{"tx_threshold":1}},
+				"contract":{"payload":"'use strict';"},
+				"metadatas":[{"key":"global_attribute","value":"` + attribute + `","version":1}]}}`))
+			return
+		}
+		var call model.CallContractRequest
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &call)
+		var input struct {
+			Method string `json:"method"`
+		}
+		json.Unmarshal([]byte(call.Input), &input)
+		value := map[string]string{"balanceOf": `{"balance":"1250"}`, "allowance": `{"allowance":"75"}`}[input.Method]
+		w.Write([]byte(`{"error_code":0,"result":{"query_rets":[{"result":{"value":` + strconv.Quote(value) + `}}]}}`))
+	}))
+}
+
+func Test_Ctp10Token(t *testing.T) {
+	var reads int
+	server := ctp10Node(&reads)
+	defer server.Close()
+	operation := token.Ctp10TokenOperation{Url: server.URL}
+	ctp10, SDKRes := operation.Token(contractAddress)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	info := ctp10.Info()
+	if info.Symbol != "TKN" || info.Decimals != 2 || info.TotalSupply.String() != "1000" || info.Owner != ownerAddress || info.Unassigned.String() != "25" {
+		t.Errorf("wrong info %+v", info)
+	}
+
+	// queries do not check the contract again
+	reads = 0
+	balance, SDKRes := ctp10.Balance(ownerAddress)
+	if SDKRes.ErrorCode != 0 || balance.String() != "12.5" {
+		t.Error(balance, SDKRes.ErrorDesc)
+	}
+	allowance, SDKRes := ctp10.Allowance(ownerAddress, spenderAddress)
+	if SDKRes.ErrorCode != 0 || allowance.String() != "0.75" {
+		t.Error(allowance, SDKRes.ErrorDesc)
+	}
+	if reads != 0 {
+		t.Errorf("%d account reads", reads)
+	}
+
+	amount, _ := model.ParseAmount("1.5", 1)
+	transfer, SDKRes := ctp10.Transfer("", spenderAddress, amount)
+	if SDKRes.ErrorCode != 0 || transfer.GetAmount() != 150 || transfer.GetContractAddress() != contractAddress || transfer.Get() != 8 {
+		t.Errorf("wrong transfer %+v %s", transfer, SDKRes.ErrorDesc)
+	}
+	tooPrecise, _ := model.ParseAmount("1.255", 3)
+	if _, SDKRes = ctp10.Approve(ownerAddress, spenderAddress, tooPrecise); SDKRes.ErrorCode != 11039 {
+		t.Error("amount below the token precision is accepted")
+	}
+	if _, SDKRes = ctp10.Assign(ownerAddress, spenderAddress, model.NewAmount(0, 2)); SDKRes.ErrorCode != 11039 {
+		t.Error("zero amount is accepted")
+	}
+	if changeOwner := ctp10.ChangeOwner(ownerAddress, spenderAddress); changeOwner.GetTokenOwner() != spenderAddress || changeOwner.Get() != 12 {
+		t.Errorf("wrong change owner %+v", changeOwner)
+	}
+}
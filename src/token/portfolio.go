@@ -0,0 +1,184 @@
+// portfolio
+package token
+
+import (
+	"sync"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// how many balances a portfolio reads from the node at the same time
+const portfolioConcurrency = 8
+
+// PortfolioOperation collects the holdings of an account.
+type PortfolioOperation struct {
+	Url     string
+	Network model.Network
+}
+
+// TokenRegistry lists the CTP10 tokens a portfolio looks for. Accounts do not
+// list the contract tokens they hold, so only registered tokens are read. The
+// registry keeps the handles of the tokens once they are checked, and may be
+// shared by portfolios read at the same time.
+type TokenRegistry struct {
+	mutex     sync.Mutex
+	contracts []string
+	tokens    map[string]*Ctp10Token
+}
+
+// NewTokenRegistry returns a registry of the CTP10 tokens at the addresses.
+func NewTokenRegistry(contractAddresses ...string) *TokenRegistry {
+	registry := &TokenRegistry{tokens: make(map[string]*Ctp10Token)}
+	for _, contractAddress := range contractAddresses {
+		registry.Add(contractAddress)
+	}
+	return registry
+}
+
+// Add registers the CTP10 token at the address, once.
+func (registry *TokenRegistry) Add(contractAddress string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	for _, registered := range registry.contracts {
+		if registered == contractAddress {
+			return
+		}
+	}
+	registry.contracts = append(registry.contracts, contractAddress)
+}
+
+// Contracts returns the addresses of the registered tokens.
+func (registry *TokenRegistry) Contracts() []string {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	return append([]string{}, registry.contracts...)
+}
+
+// token returns the handle of a registered token, checking the contract the
+// first time.
+func (registry *TokenRegistry) token(tokenOperation Ctp10TokenOperation, contractAddress string) (*Ctp10Token, exception.SDKResponse) {
+	registry.mutex.Lock()
+	token, ok := registry.tokens[contractAddress]
+	registry.mutex.Unlock()
+	if ok {
+		return token, exception.GetSDKRes(exception.SUCCESS)
+	}
+	token, SDKRes := tokenOperation.Token(contractAddress)
+	if SDKRes.ErrorCode != 0 {
+		return nil, SDKRes
+	}
+	registry.mutex.Lock()
+	registry.tokens[contractAddress] = token
+	registry.mutex.Unlock()
+	return token, SDKRes
+}
+
+// Get reads the BU balance, the assets and the balances of the registered
+// tokens of the address concurrently. Asset balances are in the decimals of
+// their ATP 1.0 metadata, or in base units for assets without it. A holding
+// that cannot be read carries its own error, and the others are still
+// returned; Get itself only fails when the account cannot be read.
+func (portfolio *PortfolioOperation) Get(address string, registry *TokenRegistry) (model.Portfolio, exception.SDKResponse) {
+	result := model.Portfolio{Address: address, Assets: []model.PortfolioHolding{}, Tokens: []model.PortfolioHolding{}}
+	if !portfolio.Network.CheckAddress(address) {
+		return result, exception.GetSDKRes(exception.INVALID_ADDRESS_ERROR)
+	}
+	Account := account.AccountOperation{Url: portfolio.Url, Network: portfolio.Network}
+	var resDataBalance model.AccountGetBalanceResponse
+	var resDataAssets model.AccountGetAssetsResponse
+	var wait sync.WaitGroup
+	wait.Add(2)
+	go func() {
+		defer wait.Done()
+		var reqData model.AccountGetBalanceRequest
+		reqData.SetAddress(address)
+		resDataBalance = Account.GetBalance(reqData)
+	}()
+	go func() {
+		defer wait.Done()
+		var reqData model.AccountGetAssetsRequest
+		reqData.SetAddress(address)
+		resDataAssets = Account.GetAssets(reqData)
+	}()
+	var contracts []string
+	if registry != nil {
+		contracts = registry.Contracts()
+	}
+	result.Tokens = make([]model.PortfolioHolding, len(contracts))
+	limit := make(chan struct{}, portfolioConcurrency)
+	for i, contractAddress := range contracts {
+		wait.Add(1)
+		go func(i int, contractAddress string) {
+			defer wait.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			result.Tokens[i] = portfolio.ctp10Holding(address, registry, contractAddress)
+		}(i, contractAddress)
+	}
+	wait.Wait()
+	if resDataBalance.ErrorCode != 0 {
+		return result, exception.SDKResponse{ErrorCode: resDataBalance.ErrorCode, ErrorDesc: resDataBalance.ErrorDesc}
+	}
+	if resDataAssets.ErrorCode != 0 && resDataAssets.ErrorCode != exception.NO_ASSET_ERROR {
+		return result, exception.SDKResponse{ErrorCode: resDataAssets.ErrorCode, ErrorDesc: resDataAssets.ErrorDesc}
+	}
+	result.BU = resDataBalance.Result.BalanceAmount()
+
+	result.Assets = make([]model.PortfolioHolding, len(resDataAssets.Result.Assets))
+	for i, asset := range resDataAssets.Result.Assets {
+		wait.Add(1)
+		go func(i int, asset model.Asset) {
+			defer wait.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			result.Assets[i] = portfolio.assetHolding(asset)
+		}(i, asset)
+	}
+	wait.Wait()
+	return result, exception.GetSDKRes(exception.SUCCESS)
+}
+
+// assetHolding reads the ATP 1.0 metadata of an asset to scale its amount.
+func (portfolio *PortfolioOperation) assetHolding(asset model.Asset) model.PortfolioHolding {
+	holding := model.PortfolioHolding{Issuer: asset.Key.Issuer, Code: asset.Key.Code, Balance: model.NewAmount(asset.Amount, 0)}
+	atp10 := Atp10Operation{Url: portfolio.Url, Network: portfolio.Network}
+	info, SDKRes := atp10.GetInfo(asset.Key.Issuer, asset.Key.Code)
+	if SDKRes.ErrorCode == exception.ATP10_ASSET_NOT_FOUND_ERROR {
+		return holding
+	}
+	if SDKRes.ErrorCode != 0 {
+		holding.ErrorCode = SDKRes.ErrorCode
+		holding.ErrorDesc = SDKRes.ErrorDesc
+		return holding
+	}
+	holding.Standard = STANDARD_ATP10
+	holding.Name = info.Metadata.Name
+	holding.Decimals = int(info.Metadata.Decimals)
+	holding.Balance = info.Metadata.Amount(asset.Amount)
+	return holding
+}
+
+// ctp10Holding reads the balance of the address in a registered token.
+func (portfolio *PortfolioOperation) ctp10Holding(address string, registry *TokenRegistry, contractAddress string) model.PortfolioHolding {
+	holding := model.PortfolioHolding{Standard: STANDARD_CTP10, ContractAddress: contractAddress}
+	token, SDKRes := registry.token(Ctp10TokenOperation{Url: portfolio.Url, Network: portfolio.Network}, contractAddress)
+	if SDKRes.ErrorCode != 0 {
+		holding.ErrorCode = SDKRes.ErrorCode
+		holding.ErrorDesc = SDKRes.ErrorDesc
+		return holding
+	}
+	info := token.Info()
+	holding.Name = info.Name
+	holding.Symbol = info.Symbol
+	holding.Decimals = info.Decimals
+	balance, SDKRes := token.Balance(address)
+	if SDKRes.ErrorCode != 0 {
+		holding.ErrorCode = SDKRes.ErrorCode
+		holding.ErrorDesc = SDKRes.ErrorDesc
+		return holding
+	}
+	holding.Balance = balance
+	return holding
+}
@@ -0,0 +1,89 @@
+// portfolio_test
+package token_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/crypto/keypair"
+	"github.com/bumoproject/bumo-sdk-go/src/token"
+)
+
+// a node where the holder has 1.5 BU, 25.50 of the ATP10 asset HNC and 7 of
+// the plain asset OLD, and 12.50 of the CTP10 token
+func portfolioNode() *httptest.Server {
+	attribute := `{\"ctp\":\"1.0\",\"name\":\"Token\",\"symbol\":\"TKN\",\"decimals\":2,\"totalSupply\":\"100000\",\"contractOwner\":\"` + ownerAddress + `\",\"balance\":\"2500\"}`
+	metadata := `{\"version\":\"1.0\",\"code\":\"HNC\",\"name\":\"Honey\",\"decimals\":2,\"totalSupply\":100000}`
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/callContract" {
+			w.Write([]byte(`{"error_code":0,"result":{"query_rets":[{"result":{"value":` + strconv.Quote(`{"balance":"1250"}`) + `}}]}}`))
+			return
+		}
+		query := r.URL.Query()
+		switch {
+		case query.Get("address") == spenderAddress:
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + spenderAddress + `","balance":150000000,"assets":[
+				{"amount":2550,"key":{"code":"HNC","issuer":"` + issuerAddress + `"}},
+				{"amount":7,"key":{"code":"OLD","issuer":"` + issuerAddress + `"}}]}}`))
+		case query.Get("address") == contractAddress:
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + contractAddress + `",
+				"priv":{"master_weight":0,"thresholds":{"tx_threshold":1}},
+				"contract":{"payload":"'use strict';"},
+				"metadatas":[{"key":"global_attribute","value":"` + attribute + `","version":1}]}}`))
+		case query.Get("address") == issuerAddress && query.Get("key") == "asp_HNC":
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + issuerAddress + `","metadatas":[{"key":"asp_HNC","value":"` + metadata + `","version":1}]}}`))
+		case query.Get("address") == issuerAddress:
+			w.Write([]byte(`{"error_code":0,"result":{"address":"` + issuerAddress + `"}}`))
+		default:
+			w.Write([]byte(`{"error_code":4,"error_desc":"Account not exist"}`))
+		}
+	}))
+}
+
+func Test_Portfolio(t *testing.T) {
+	server := portfolioNode()
+	defer server.Close()
+	_, _, missingAddress, err := keypair.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := token.NewTokenRegistry(contractAddress, missingAddress, contractAddress)
+	if len(registry.Contracts()) != 2 {
+		t.Fatalf("wrong contracts %v", registry.Contracts())
+	}
+	portfolio := token.PortfolioOperation{Url: server.URL}
+	result, SDKRes := portfolio.Get(spenderAddress, registry)
+	if SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if result.BU.String() != "1.5" {
+		t.Errorf("wrong BU %s", result.BU)
+	}
+	if len(result.Assets) != 2 || len(result.Tokens) != 2 {
+		t.Fatalf("wrong holdings %+v", result)
+	}
+	hnc, old := result.Assets[0], result.Assets[1]
+	if hnc.Standard != token.STANDARD_ATP10 || hnc.Name != "Honey" || hnc.Balance.String() != "25.5" || hnc.ErrorCode != 0 {
+		t.Errorf("wrong ATP10 asset %+v", hnc)
+	}
+	if old.Standard != "" || old.Code != "OLD" || old.Balance.String() != "7" || old.ErrorCode != 0 {
+		t.Errorf("wrong asset %+v", old)
+	}
+	tkn, missing := result.Tokens[0], result.Tokens[1]
+	if tkn.Standard != token.STANDARD_CTP10 || tkn.Symbol != "TKN" || tkn.Balance.String() != "12.5" || tkn.ErrorCode != 0 {
+		t.Errorf("wrong token %+v", tkn)
+	}
+	// a token that cannot be read does not fail the portfolio
+	if missing.ContractAddress != missingAddress || missing.ErrorCode == 0 {
+		t.Errorf("missing token is read %+v", missing)
+	}
+
+	if _, SDKRes = portfolio.Get(missingAddress, registry); SDKRes.ErrorCode != 4 {
+		t.Error("missing account is read:", SDKRes.ErrorDesc)
+	}
+	if _, SDKRes = portfolio.Get("bu", nil); SDKRes.ErrorCode != 11006 {
+		t.Error("invalid address is read:", SDKRes.ErrorDesc)
+	}
+}
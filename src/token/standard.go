@@ -0,0 +1,162 @@
+// standard
+package token
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/bumoproject/bumo-sdk-go/src/account"
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/exception"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+)
+
+// STANDARD_CTP10 is the name of the CTP 1.0 token standard.
+const STANDARD_CTP10 string = "CTP10"
+
+// TokenStandard describes a contract token standard. A contract implements it
+// when the JSON object stored under AttributeKey in its metadata has Version
+// in its VersionField. Payload is the code issuing a token of the standard,
+// and Interface its methods and events.
+type TokenStandard struct {
+	Name         string
+	AttributeKey string
+	VersionField string
+	Version      string
+	Payload      string
+	Interface    contract.ContractInterface
+}
+
+// Ctp10Standard returns the CTP 1.0 standard of model.Payload.
+func Ctp10Standard() TokenStandard {
+	return TokenStandard{
+		Name:         STANDARD_CTP10,
+		AttributeKey: "global_attribute",
+		VersionField: "ctp",
+		Version:      "1.0",
+		Payload:      model.Payload,
+		Interface:    ctp10Interface,
+	}
+}
+
+// Check reports a standard that cannot be detected or used.
+func (standard TokenStandard) Check() exception.SDKResponse {
+	desc := ""
+	switch {
+	case standard.Name == "":
+		desc = "the name is empty"
+	case standard.AttributeKey == "" || standard.VersionField == "" || standard.Version == "":
+		desc = standard.Name + " has no attribute key, version field or version"
+	}
+	if desc != "" {
+		SDKRes := exception.GetSDKRes(exception.INVALID_TOKEN_STANDARD_ERROR)
+		SDKRes.ErrorDesc += ": " + desc
+		return SDKRes
+	}
+	SDKRes := standard.Interface.Check()
+	if SDKRes.ErrorCode != 0 {
+		SDKRes.ErrorCode = exception.INVALID_TOKEN_STANDARD_ERROR
+		SDKRes.ErrorDesc = exception.GetErrDesc(SDKRes.ErrorCode) + ": " + standard.Name + ": " + SDKRes.ErrorDesc
+	}
+	return SDKRes
+}
+
+// Matches reports whether the attribute of a contract is of the standard.
+func (standard TokenStandard) Matches(attribute map[string]interface{}) bool {
+	version, ok := attribute[standard.VersionField].(string)
+	return ok && version == standard.Version
+}
+
+// CreateOperation builds the operation issuing a token of the standard, with
+// the init input its init function takes.
+func (standard TokenStandard) CreateOperation(initBalance int64, initInput string) model.ContractCreateOperation {
+	var operation model.ContractCreateOperation
+	operation.Init()
+	operation.SetPayload(standard.Payload)
+	operation.SetInitBalance(initBalance)
+	operation.SetInitInput(initInput)
+	return operation
+}
+
+// StandardRegistry holds the token standards the SDK knows, in the order
+// they are tried by Detect.
+type StandardRegistry struct {
+	standards []TokenStandard
+}
+
+// NewStandardRegistry returns a registry holding CTP 1.0.
+func NewStandardRegistry() *StandardRegistry {
+	return &StandardRegistry{standards: []TokenStandard{Ctp10Standard()}}
+}
+
+// Register adds a standard, or replaces the standard of the same name.
+func (registry *StandardRegistry) Register(standard TokenStandard) exception.SDKResponse {
+	SDKRes := standard.Check()
+	if SDKRes.ErrorCode != 0 {
+		return SDKRes
+	}
+	for i := range registry.standards {
+		if registry.standards[i].Name == standard.Name {
+			registry.standards[i] = standard
+			return SDKRes
+		}
+	}
+	registry.standards = append(registry.standards, standard)
+	return SDKRes
+}
+
+// Get finds a standard by name.
+func (registry *StandardRegistry) Get(name string) (TokenStandard, bool) {
+	for _, standard := range registry.standards {
+		if standard.Name == name {
+			return standard, true
+		}
+	}
+	return TokenStandard{}, false
+}
+
+// Standards returns the registered standards.
+func (registry *StandardRegistry) Standards() []TokenStandard {
+	return append([]TokenStandard{}, registry.standards...)
+}
+
+// Detect tells which registered standard the contract at the address
+// implements, reading each attribute key of the standards once. It fails with
+// UNKNOWN_TOKEN_STANDARD_ERROR when none matches. The contract is read on the
+// node and network of Contract.
+func (registry *StandardRegistry) Detect(Contract contract.ContractOperation, contractAddress string) (TokenStandard, exception.SDKResponse) {
+	var reqDataCheck model.ContractCheckValidRequest
+	reqDataCheck.SetAddress(contractAddress)
+	resDataCheck := Contract.CheckValid(reqDataCheck)
+	if resDataCheck.ErrorCode != 0 {
+		return TokenStandard{}, exception.SDKResponse{ErrorCode: resDataCheck.ErrorCode, ErrorDesc: resDataCheck.ErrorDesc}
+	}
+	if !resDataCheck.Result.IsValid {
+		return TokenStandard{}, exception.GetSDKRes(exception.INVALID_CONTRACTADDRESS_ERROR)
+	}
+	Account := account.AccountOperation{Url: Contract.Url, Network: Contract.Network}
+	attributes := make(map[string]map[string]interface{})
+	for _, standard := range registry.standards {
+		attribute, read := attributes[standard.AttributeKey]
+		if !read {
+			var reqData model.AccountGetMetadataRequest
+			reqData.SetAddress(contractAddress)
+			reqData.SetKey(standard.AttributeKey)
+			resData := Account.GetMetadata(reqData)
+			if resData.ErrorCode != 0 && resData.ErrorCode != exception.NO_METADATA_ERROR {
+				return TokenStandard{}, exception.SDKResponse{ErrorCode: resData.ErrorCode, ErrorDesc: resData.ErrorDesc}
+			}
+			if resData.ErrorCode == 0 && len(resData.Result.Metadatas) != 0 {
+				decoder := json.NewDecoder(strings.NewReader(resData.Result.Metadatas[0].Value))
+				decoder.UseNumber()
+				// an attribute that is not a JSON object matches no standard
+				decoder.Decode(&attribute)
+			}
+			attributes[standard.AttributeKey] = attribute
+		}
+		if standard.Matches(attribute) {
+			return standard, exception.GetSDKRes(exception.SUCCESS)
+		}
+	}
+	return TokenStandard{}, exception.GetSDKRes(exception.UNKNOWN_TOKEN_STANDARD_ERROR)
+}
@@ -0,0 +1,62 @@
+// standard_test
+package token_test
+
+import (
+	"testing"
+
+	"github.com/bumoproject/bumo-sdk-go/src/contract"
+	"github.com/bumoproject/bumo-sdk-go/src/model"
+	"github.com/bumoproject/bumo-sdk-go/src/token"
+)
+
+func Test_StandardRegistry(t *testing.T) {
+	var reads int
+	server := ctp10Node(&reads)
+	defer server.Close()
+	registry := token.NewStandardRegistry()
+	ctp20 := token.TokenStandard{
+		Name:         "CTP20",
+		AttributeKey: "global_attribute",
+		VersionField: "ctp",
+		Version:      "2.0",
+		Payload:      "'use strict';function init(input){}function main(input){}",
+	}
+	if SDKRes := registry.Register(ctp20); SDKRes.ErrorCode != 0 {
+		t.Fatal(SDKRes.ErrorDesc)
+	}
+	if SDKRes := registry.Register(token.TokenStandard{Name: "ATP20"}); SDKRes.ErrorCode != 11084 {
+		t.Error("standard without version is registered")
+	}
+	broken := ctp20
+	broken.Interface = contract.ContractInterface{Methods: []contract.ContractMethod{{Name: "f", Inputs: []contract.ContractParam{{Name: "a", Type: "float"}}}}}
+	if SDKRes := registry.Register(broken); SDKRes.ErrorCode != 11084 {
+		t.Error("standard with a broken interface is registered")
+	}
+	if len(registry.Standards()) != 2 {
+		t.Errorf("%d standards", len(registry.Standards()))
+	}
+
+	reads = 0
+	standard, SDKRes := registry.Detect(contract.ContractOperation{Url: server.URL}, contractAddress)
+	if SDKRes.ErrorCode != 0 || standard.Name != token.STANDARD_CTP10 || standard.Payload != model.Payload {
+		t.Fatal(standard.Name, SDKRes.ErrorDesc)
+	}
+
+	// a registry whose CTP10 is another version does not know the contract
+	registry = token.NewStandardRegistry()
+	ctp20.Name = token.STANDARD_CTP10
+	registry.Register(ctp20)
+	reads = 0
+	if _, SDKRes = registry.Detect(contract.ContractOperation{Url: server.URL}, contractAddress); SDKRes.ErrorCode != 11085 {
+		t.Error("unknown standard is detected:", SDKRes.ErrorDesc)
+	}
+	// the contract check and the attribute
+	if reads != 2 {
+		t.Errorf("%d account reads", reads)
+	}
+
+	operation := token.Ctp10Standard().CreateOperation(10000000, `{"params":{"name":"Token","symbol":"TKN","decimals":2,"supply":"1000"}}`)
+	if operation.GetPayload() != model.Payload || operation.GetInitBalance() != 10000000 {
+		t.Error("wrong create operation")
+	}
+}